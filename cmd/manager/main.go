@@ -4,8 +4,11 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
 	"runtime"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
@@ -17,16 +20,21 @@ import (
 	"github.com/spf13/pflag"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/dynamic"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"sigs.k8s.io/controller-runtime/pkg/runtime/signals"
 
 	"github.com/redhat-developer/service-binding-operator/pkg/apis"
+	operatorconfig "github.com/redhat-developer/service-binding-operator/pkg/config"
 	"github.com/redhat-developer/service-binding-operator/pkg/controller"
+	"github.com/redhat-developer/service-binding-operator/pkg/controller/servicebinding"
 	"github.com/redhat-developer/service-binding-operator/pkg/log"
+	"github.com/redhat-developer/service-binding-operator/pkg/tracing"
 )
 
 // Change below variables to serve metrics on different host or port.
@@ -34,6 +42,7 @@ var (
 	metricsHost               = "0.0.0.0"
 	metricsPort         int32 = 8383
 	operatorMetricsPort int32 = 8686
+	healthProbePort     int32 = 8081
 	mainLog                   = log.NewLog("main")
 )
 
@@ -53,14 +62,32 @@ func getOperatorName() string {
 	return "service-binding-operator"
 }
 
-// isLeaderElectionEnabled based on environment variable SERVICE_BINDING_OPERATOR_DISABLE_ELECTION. By default, it is enabled.
-func isLeaderElectionEnabled() bool {
-	return os.Getenv("SERVICE_BINDING_OPERATOR_DISABLE_ELECTION") == ""
+// getConfigNamespace returns the namespace of the OperatorConfig resource: the operator namespace, or
+// the first watched namespace when running locally.
+func getConfigNamespace(env operatorconfig.Config) string {
+	ns, err := k8sutil.GetOperatorNamespace()
+	if err == nil {
+		return ns
+	}
+	if len(env.WatchNamespaces) > 0 {
+		return env.WatchNamespaces[0]
+	}
+	return ""
 }
 
-// isLeaderWithLeaseEnabled based on environment variable SERVICE_BINDING_OPERATOR_LEADER_ELECTION_OPTION. By default, it is leader-for-life.
-func isLeaderWithLeaseEnabled() bool {
-	return os.Getenv("SERVICE_BINDING_OPERATOR_LEADER_ELECTION_OPTION") == "leader-with-lease"
+// loadConfig returns the operator configuration, given by the environment variables and the
+// OperatorConfig resource.
+func loadConfig(client dynamic.Interface, ns string) operatorconfig.Config {
+	if ns == "" {
+		mainLog.Info("Operator namespace is unknown, using the environment configuration")
+		return operatorconfig.FromEnv()
+	}
+	c, err := operatorconfig.Load(client, ns)
+	if err != nil {
+		mainLog.Error(err, "Failed to read the operator configuration, using the environment configuration")
+		return operatorconfig.FromEnv()
+	}
+	return c
 }
 
 func main() {
@@ -71,49 +98,86 @@ func main() {
 
 	printVersion()
 
-	namespace, err := k8sutil.GetWatchNamespace()
+	// Get a config to talk to the apiserver
+	cfg, err := config.GetConfig()
 	if err != nil {
-		mainLog.Error(err, "Failed to get watch namespace")
+		mainLog.Error(err, "Failed to acquire a configuration to talk to the API server")
 		os.Exit(1)
 	}
 
-	// Get a config to talk to the apiserver
-	cfg, err := config.GetConfig()
+	dynClient, err := dynamic.NewForConfig(cfg)
 	if err != nil {
-		mainLog.Error(err, "Failed to acquire a configuration to talk to the API server")
+		mainLog.Error(err, "Failed to create a dynamic client")
 		os.Exit(1)
 	}
 
+	// the operator configuration is read once here, its settings applied without a restart are then
+	// reloaded on change
+	configNamespace := getConfigNamespace(operatorconfig.FromEnv())
+	operatorConfig := loadConfig(dynClient, configNamespace)
+	operatorconfig.Set(operatorConfig)
+
+	namespace := strings.Join(operatorConfig.WatchNamespaces, ",")
+
 	ctx := context.TODO()
 
+	shutdownTracing, err := tracing.Setup(ctx, getOperatorName())
+	if err != nil {
+		mainLog.Error(err, "Failed to setup tracing")
+		os.Exit(1)
+	}
+
 	opts := manager.Options{
 		Namespace:          namespace,
 		MetricsBindAddress: fmt.Sprintf("%s:%d", metricsHost, metricsPort),
 	}
 
 	// FIXME: is there a way to tell k8s-client that is not running in-cluster?
-	if isLeaderElectionEnabled() {
-		if !isLeaderWithLeaseEnabled() {
+	if operatorConfig.Sharding.Enabled {
+		// every replica is active, reconciling the ServiceBindings of its shard
+		mainLog.Info("Namespaces are sharded across the operator replicas, leader election is disabled")
+	} else if leaderElection := operatorConfig.LeaderElection; leaderElection.Enabled {
+		if leaderElection.Mode != operatorconfig.LeaderWithLease {
+			// the probes are served while waiting, so the pod isn't restarted meanwhile
+			stopProbes, err := serveStandbyProbes(fmt.Sprintf("%s:%d", metricsHost, healthProbePort))
+			if err != nil {
+				mainLog.Error(err, "Failed to serve the probes")
+				os.Exit(1)
+			}
 			// Become the leader before proceeding
 			err = leader.Become(ctx, fmt.Sprintf("%s-lock", getOperatorName()))
+			stopProbes()
 			if err != nil {
 				mainLog.Error(err, "Failed to become the leader")
 				os.Exit(1)
 			}
 		} else {
-			leaderNS := os.Getenv("SERVICE_BINDING_OPERATOR_LEADER_ELECTION_NAMESPACE")
 			opts = manager.Options{
 				Namespace:               namespace,
 				MetricsBindAddress:      fmt.Sprintf("%s:%d", metricsHost, metricsPort),
 				LeaderElection:          true,
 				LeaderElectionID:        getOperatorName(),
-				LeaderElectionNamespace: leaderNS,
+				LeaderElectionNamespace: leaderElection.Namespace,
 			}
 		}
 	} else {
 		mainLog.Warning("Leader election is disabled")
 	}
 
+	// /healthz and /readyz are served for the pod probes
+	opts.HealthProbeBindAddress = fmt.Sprintf("%s:%d", metricsHost, healthProbePort)
+
+	// resources are discovered lazily, and discovered again when CRDs are installed
+	opts.MapperProvider = servicebinding.NewRESTMapper
+
+	// several comma-separated namespaces are watched through a cache per namespace
+	if namespaces := operatorConfig.WatchNamespaces; len(namespaces) > 1 {
+		opts.Namespace = ""
+		opts.NewCache = servicebinding.NewMultiNamespaceCache(namespaces)
+		// the ServiceMonitor is created in the first watched namespace
+		namespace = namespaces[0]
+	}
+
 	// Create a new Cmd to provide shared dependencies and start components
 	mgr, err := manager.New(cfg, opts)
 	if err != nil {
@@ -129,12 +193,24 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Setup all Controllers
+	// Setup all Controllers, which register their readiness checks
 	if err := controller.AddToManager(mgr); err != nil {
 		mainLog.Error(err, "Failed to setup the controller manager")
 		os.Exit(1)
 	}
 
+	if err := mgr.AddHealthzCheck("ping", healthz.Ping); err != nil {
+		mainLog.Error(err, "Failed to add the liveness check")
+		os.Exit(1)
+	}
+
+	if configNamespace != "" {
+		if err := mgr.Add(operatorconfig.NewReloader(dynClient, configNamespace, operatorConfig)); err != nil {
+			mainLog.Error(err, "Failed to watch the operator configuration")
+			os.Exit(1)
+		}
+	}
+
 	if err = serveCRMetrics(cfg); err != nil {
 		mainLog.Info("Could not generate and serve custom resource metrics", "error", err.Error())
 	}
@@ -166,12 +242,48 @@ func main() {
 	mainLog.Info("Starting the Cmd.")
 
 	// Start the Cmd
-	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
+	err = mgr.Start(signals.SetupSignalHandler())
+
+	// Flush the spans still pending export
+	if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+		mainLog.Error(shutdownErr, "Failed to shutdown tracing")
+	}
+
+	if err != nil {
 		mainLog.Error(err, "Manager exited non-zero")
 		os.Exit(1)
 	}
 }
 
+// serveStandbyProbes serves the pod probes at the given address until the returned function is
+// called, while the operator waits to become the leader: the operator is alive, but not ready as it
+// doesn't reconcile anything yet.
+func serveStandbyProbes(addr string) (func(), error) {
+	// listening right away makes sure the address is free once stopped
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "ok")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "waiting to become the leader", http.StatusServiceUnavailable)
+	})
+	server := &http.Server{Handler: mux}
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			mainLog.Error(err, "Failed to serve the probes")
+		}
+	}()
+	return func() {
+		if err := server.Shutdown(context.Background()); err != nil {
+			mainLog.Error(err, "Failed to stop serving the probes")
+		}
+	}, nil
+}
+
 // serveCRMetrics gets the Operator/CustomResource GVKs and generates metrics based on those types.
 // It serves those metrics on "http://metricsHost:operatorMetricsPort".
 func serveCRMetrics(cfg *rest.Config) error {
@@ -1,13 +1,13 @@
 module github.com/redhat-developer/service-binding-operator
 
-go 1.14
+go 1.15
 
 require (
 	cloud.google.com/go v0.45.1 // indirect
 	github.com/coreos/etcd-operator v0.9.4
 	github.com/go-logr/logr v0.1.0
 	github.com/go-openapi/spec v0.19.4
-	github.com/google/go-cmp v0.3.1
+	github.com/google/go-cmp v0.5.6
 	github.com/google/go-containerregistry v0.0.0-20191218175032-34fb8ff33bed // indirect
 	github.com/imdario/mergo v0.3.8
 	github.com/mitchellh/copystructure v1.0.0
@@ -17,8 +17,15 @@ require (
 	github.com/operator-framework/operator-lifecycle-manager v0.0.0-20200130164400-12c06cfc05c4
 	github.com/operator-framework/operator-sdk v0.16.0
 	github.com/pkg/errors v0.8.1
+	github.com/prometheus/client_golang v1.2.1
 	github.com/spf13/pflag v1.0.5
-	github.com/stretchr/testify v1.6.1
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/otel v1.0.1
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace v1.0.1
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc v1.0.1
+	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.0.1
+	go.opentelemetry.io/otel/sdk v1.0.1
+	go.opentelemetry.io/otel/trace v1.0.1
 	gotest.tools v2.2.0+incompatible
 	k8s.io/api v0.17.1
 	k8s.io/apiextensions-apiserver v0.17.1
@@ -31,6 +38,7 @@ require (
 	knative.dev/serving v0.9.0
 	sigs.k8s.io/controller-runtime v0.4.0
 	sigs.k8s.io/controller-tools v0.2.4
+	sigs.k8s.io/yaml v1.1.0
 )
 
 replace github.com/Azure/go-autorest => github.com/Azure/go-autorest v12.2.0+incompatible
@@ -8,6 +8,8 @@ $HACK_DIR/remove-sbr-finalizers.sh
 # Delete deployed resources
 RES_FILES=(
         crds/operators.coreos.com_servicebindings_crd.yaml
+        crds/operators.coreos.com_operatorconfigs_crd.yaml
+        crds/operators.coreos.com_servicebindingdefaults_crd.yaml
         operator.yaml
         role_binding.yaml
         role.yaml
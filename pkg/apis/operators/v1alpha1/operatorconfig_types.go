@@ -0,0 +1,160 @@
+package v1alpha1
+
+import (
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OperatorConfigSpec defines the desired configuration of the operator. Unset fields keep the value
+// given by the operator environment variables, or their default.
+type OperatorConfigSpec struct {
+	// WatchNamespaces are the namespaces watched by the operator; all namespaces are watched when
+	// empty. Applied on restart.
+	// +optional
+	// +listType=set
+	WatchNamespaces []string `json:"watchNamespaces,omitempty"`
+
+	// LeaderElection configures the election of the active operator replica. Applied on restart.
+	// +optional
+	LeaderElection *LeaderElectionConfig `json:"leaderElection,omitempty"`
+
+	// Sharding spreads the reconciliation of ServiceBindings across all operator replicas, each
+	// owning a share of the namespaces; leader election is disabled when enabled. Applied on
+	// restart.
+	// +optional
+	Sharding *ShardingConfig `json:"sharding,omitempty"`
+
+	// MaxConcurrentReconciles is the number of ServiceBindings reconciled concurrently. Applied on
+	// restart.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
+
+	// RetryBackoff configures the delay before reconciling again a ServiceBinding whose
+	// reconciliation failed.
+	// +optional
+	RetryBackoff *RetryBackoffConfig `json:"retryBackoff,omitempty"`
+
+	// LogLevel is the most verbose level logged by the operator: "info", "debug" or "trace".
+	// +kubebuilder:validation:Enum:=info;debug;trace
+	// +optional
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// RequeueAfterSeconds is the delay before reconciling again a ServiceBinding whose application
+	// isn't found.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	RequeueAfterSeconds int64 `json:"requeueAfterSeconds,omitempty"`
+
+	// DefaultMountPath is the path binding volumes are mounted at when the ServiceBinding declares no
+	// mount path prefix.
+	// +optional
+	DefaultMountPath string `json:"defaultMountPath,omitempty"`
+
+	// DefaultContainersPath is the path of the containers in application workloads, when the
+	// ServiceBinding declares no binding path.
+	// +optional
+	DefaultContainersPath string `json:"defaultContainersPath,omitempty"`
+
+	// DebugAddress is the address the operator serves its debug endpoints at, e.g. ":8082", on
+	// localhost unless a host is given; debug endpoints are disabled when empty. Applied on restart.
+	// +optional
+	DebugAddress string `json:"debugAddress,omitempty"`
+
+	// FeatureGates enables or disables operator features by name.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+}
+
+// LeaderElectionConfig configures the election of the active operator replica.
+type LeaderElectionConfig struct {
+	// Enabled tells whether a leader is elected among the operator replicas.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Mode is how the leader is elected: "leader-for-life" or "leader-with-lease".
+	// +kubebuilder:validation:Enum:=leader-for-life;leader-with-lease
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// Namespace holds the lease when electing the leader with a lease.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ShardingConfig configures the sharding of namespaces across the operator replicas.
+type ShardingConfig struct {
+	// Enabled tells whether namespaces are sharded across the operator replicas.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// LeaseDurationSeconds is how long a replica keeps its shard without renewing its lease; the
+	// namespaces of a replica which stopped are taken over by the others once its lease expires.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	LeaseDurationSeconds int32 `json:"leaseDurationSeconds,omitempty"`
+}
+
+// RetryBackoffConfig configures the exponential backoff between retries of a failed reconciliation.
+type RetryBackoffConfig struct {
+	// InitialDelay is the delay before the first retry, doubled on each consecutive failure, e.g.
+	// "500ms".
+	// +optional
+	InitialDelay *metav1.Duration `json:"initialDelay,omitempty"`
+
+	// MaxDelay caps the delay between retries, e.g. "5m".
+	// +optional
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
+
+	// JitterPercent is the upper bound of the random delay added to each retry, as a percentage of
+	// the delay, so ServiceBindings failing together aren't retried together.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=100
+	// +optional
+	JitterPercent *int32 `json:"jitterPercent,omitempty"`
+}
+
+// OperatorConfigStatus defines the observed state of OperatorConfig
+// +k8s:openapi-gen=true
+type OperatorConfigStatus struct {
+	// ObservedGeneration is the generation of the configuration last processed by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describes whether the configuration is applied, and whether a restart is required
+	// to apply it entirely.
+	// +optional
+	// +listType=set
+	Conditions []conditionsv1.Condition `json:"conditions,omitempty"`
+	// Applied is the configuration in effect in the operator.
+	// +optional
+	Applied *OperatorConfigSpec `json:"applied,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OperatorConfig configures the Service Binding Operator.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="Operator Config"
+// +kubebuilder:resource:path=operatorconfigs
+type OperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OperatorConfigSpec   `json:"spec,omitempty"`
+	Status OperatorConfigStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OperatorConfigList contains a list of OperatorConfig
+type OperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []OperatorConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OperatorConfig{}, &OperatorConfigList{})
+}
@@ -35,6 +35,11 @@ type ServiceBindingSpec struct {
 	// different subresources owned by backing operator CR.
 	// +optional
 	DetectBindingResources *bool `json:"detectBindingResources,omitempty"`
+
+	// BindingResourcesDetection configures how the resources related to the backing services are
+	// detected when DetectBindingResources is enabled.
+	// +optional
+	BindingResourcesDetection *BindingResourcesDetection `json:"bindingResourcesDetection,omitempty"`
 }
 
 // ServiceBindingStatus defines the observed state of ServiceBinding
@@ -49,6 +54,40 @@ type ServiceBindingStatus struct {
 	// +optional
 	// +listType=set
 	Applications []BoundApplication `json:"applications,omitempty"`
+	// AnnotationErrors contain the binding annotations which couldn't be processed
+	// +optional
+	// +listType=set
+	AnnotationErrors []AnnotationError `json:"annotationErrors,omitempty"`
+	// DetectedResources contain the resources related to the backing services which contributed
+	// binding data
+	// +optional
+	// +listType=set
+	DetectedResources []DetectedResource `json:"detectedResources,omitempty"`
+	// Services contain the resolution state of each backing service
+	// +optional
+	// +listType=set
+	Services []ServiceStatus `json:"services,omitempty"`
+	// Effective contains the settings the ServiceBinding is bound with: the ones it declares, then
+	// the namespace defaults, then the operator defaults
+	// +optional
+	Effective *EffectiveSettings `json:"effective,omitempty"`
+}
+
+// EffectiveSettings defines the settings a ServiceBinding is bound with.
+type EffectiveSettings struct {
+	// MountPathPrefix is the prefix for volume mount
+	MountPathPrefix string `json:"mountPathPrefix"`
+	// EnvVarPrefix is the prefix for environment variables
+	// +optional
+	EnvVarPrefix string `json:"envVarPrefix,omitempty"`
+	// DetectBindingResources tells whether the resources owned by the backing services are detected
+	DetectBindingResources bool `json:"detectBindingResources"`
+	// BindingPath is the path in the application workload where the binding is referenced
+	// +optional
+	BindingPath *BindingPath `json:"bindingPath,omitempty"`
+	// Defaults is the name of the ServiceBindingDefaults the settings were taken from, if any
+	// +optional
+	Defaults string `json:"defaults,omitempty"`
 }
 
 // Service defines the selector based on resource name, version, and resource kind
@@ -60,6 +99,18 @@ type Service struct {
 	Namespace    *string `json:"namespace,omitempty"`
 	EnvVarPrefix *string `json:"envVarPrefix,omitempty"`
 	Id           *string `json:"id,omitempty"`
+
+	// ReadyCondition is the type of the service status condition which must be True before the
+	// service is bound
+	// +optional
+	ReadyCondition *string `json:"readyCondition,omitempty"`
+	// ReadyPath is a JSONPath expression, e.g. "{.status.credentials.password}", which must find a
+	// value other than an empty or false one before the service is bound
+	// +optional
+	ReadyPath *string `json:"readyPath,omitempty"`
+	// Optional services which can't be resolved are skipped, and bound once they're resolved
+	// +optional
+	Optional *bool `json:"optional,omitempty"`
 }
 
 // BoundApplication defines the application workloads to which the binding secret has
@@ -69,6 +120,88 @@ type BoundApplication struct {
 	corev1.LocalObjectReference `json:",inline"`
 }
 
+// AnnotationError describes a binding annotation, declared by a backing service, which couldn't be
+// processed.
+type AnnotationError struct {
+	metav1.GroupVersionKind     `json:",inline"`
+	corev1.LocalObjectReference `json:",inline"`
+
+	// Namespace is the backing service's namespace
+	Namespace string `json:"namespace"`
+	// Key is the annotation key
+	Key string `json:"key"`
+	// Message describes the error found processing the annotation
+	Message string `json:"message"`
+}
+
+// BindingResourcesDetection defines how the resources related to a backing service are detected.
+type BindingResourcesDetection struct {
+	// OwnershipDepth is the number of owner references followed from a resource to reach the
+	// backing service. The default, 1, detects the resources directly owned by the backing service.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=5
+	OwnershipDepth *int32 `json:"ownershipDepth,omitempty"`
+
+	// LabelAssociations detect, in addition to owned resources, the resources having all the given
+	// labels in the backing service's namespace.
+	// +optional
+	// +listType=set
+	LabelAssociations []LabelAssociation `json:"labelAssociations,omitempty"`
+}
+
+// LabelAssociation defines a label associating resources to a backing service.
+type LabelAssociation struct {
+	// Key is the label key, e.g. "app.kubernetes.io/instance"
+	Key string `json:"key"`
+	// Value is the label value; the backing service's name when empty
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// DetectedResource defines a resource related to a backing service which contributed binding
+// data.
+type DetectedResource struct {
+	metav1.GroupVersionKind     `json:",inline"`
+	corev1.LocalObjectReference `json:",inline"`
+
+	// Namespace is the resource's namespace
+	Namespace string `json:"namespace"`
+	// Association is how the resource is related to the backing service: "Ownership" or "Labels"
+	Association string `json:"association"`
+}
+
+// ServiceState is the resolution state of a backing service.
+type ServiceState string
+
+const (
+	// ServiceResolved is the state of a backing service contributing binding data.
+	ServiceResolved ServiceState = "Resolved"
+	// ServiceMissing is the state of a backing service, or a kind of service, which can't be found.
+	ServiceMissing ServiceState = "Missing"
+	// ServiceNotReady is the state of a backing service which doesn't pass its readiness gate.
+	ServiceNotReady ServiceState = "NotReady"
+	// ServiceError is the state of a backing service which couldn't be resolved.
+	ServiceError ServiceState = "Error"
+)
+
+// ServiceStatus defines the resolution state of a backing service.
+type ServiceStatus struct {
+	metav1.GroupVersionKind     `json:",inline"`
+	corev1.LocalObjectReference `json:",inline"`
+
+	// Namespace is the backing service's namespace
+	Namespace string `json:"namespace"`
+	// Optional tells whether the backing service is optional
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+	// State is "Resolved", "Missing", "NotReady" or "Error"
+	State ServiceState `json:"state"`
+	// Message describes why the backing service isn't resolved
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // Application defines the selector based on labels and GVR
 type Application struct {
 	corev1.LocalObjectReference `json:",inline"`
@@ -0,0 +1,55 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServiceBindingDefaultsSpec defines the settings applied to the ServiceBindings of the namespace
+// which leave them unset.
+type ServiceBindingDefaultsSpec struct {
+	// MountPathPrefix is the default prefix for volume mount
+	// +optional
+	MountPathPrefix string `json:"mountPathPrefix,omitempty"`
+
+	// EnvVarPrefix is the default prefix for environment variables
+	// +optional
+	EnvVarPrefix string `json:"envVarPrefix,omitempty"`
+
+	// DetectBindingResources is the default for binding all non-bindable variables from different
+	// subresources owned by backing operator CR.
+	// +optional
+	DetectBindingResources *bool `json:"detectBindingResources,omitempty"`
+
+	// BindingPath is the default path in application workloads where the binding would be
+	// referenced.
+	// +optional
+	BindingPath *BindingPath `json:"bindingPath,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceBindingDefaults declares the default settings of the ServiceBindings in its namespace. Only
+// the ServiceBindingDefaults named "default" is applied.
+// +k8s:openapi-gen=true
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="Service Binding Defaults"
+// +kubebuilder:resource:path=servicebindingdefaults
+type ServiceBindingDefaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceBindingDefaultsSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceBindingDefaultsList contains a list of ServiceBindingDefaults
+type ServiceBindingDefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ServiceBindingDefaults `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServiceBindingDefaults{}, &ServiceBindingDefaultsList{})
+}
@@ -27,8 +27,101 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.ServiceBinding":       schema_pkg_apis_operators_v1alpha1_ServiceBinding(ref),
-		"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.ServiceBindingStatus": schema_pkg_apis_operators_v1alpha1_ServiceBindingStatus(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.OperatorConfig":         schema_pkg_apis_operators_v1alpha1_OperatorConfig(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.OperatorConfigStatus":   schema_pkg_apis_operators_v1alpha1_OperatorConfigStatus(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.ServiceBinding":         schema_pkg_apis_operators_v1alpha1_ServiceBinding(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.ServiceBindingDefaults": schema_pkg_apis_operators_v1alpha1_ServiceBindingDefaults(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.ServiceBindingStatus":   schema_pkg_apis_operators_v1alpha1_ServiceBindingStatus(ref),
+	}
+}
+
+func schema_pkg_apis_operators_v1alpha1_OperatorConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OperatorConfig configures the Service Binding Operator.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.OperatorConfigSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.OperatorConfigStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.OperatorConfigSpec", "github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.OperatorConfigStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_operators_v1alpha1_OperatorConfigStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OperatorConfigStatus defines the observed state of OperatorConfig",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the generation of the configuration last processed by the operator.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions describes whether the configuration is applied, and whether a restart is required to apply it entirely.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/openshift/custom-resource-status/conditions/v1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"applied": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Applied is the configuration in effect in the operator.",
+							Ref:         ref("github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.OperatorConfigSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/openshift/custom-resource-status/conditions/v1.Condition", "github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.OperatorConfigSpec"},
 	}
 }
 
@@ -77,6 +170,45 @@ func schema_pkg_apis_operators_v1alpha1_ServiceBinding(ref common.ReferenceCallb
 	}
 }
 
+func schema_pkg_apis_operators_v1alpha1_ServiceBindingDefaults(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceBindingDefaults declares the default settings of the ServiceBindings in its namespace. Only the ServiceBindingDefaults named \"default\" is applied.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.ServiceBindingDefaultsSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.ServiceBindingDefaultsSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
 func schema_pkg_apis_operators_v1alpha1_ServiceBindingStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -127,11 +259,71 @@ func schema_pkg_apis_operators_v1alpha1_ServiceBindingStatus(ref common.Referenc
 							},
 						},
 					},
+					"annotationErrors": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "AnnotationErrors contain the binding annotations which couldn't be processed",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.AnnotationError"),
+									},
+								},
+							},
+						},
+					},
+					"detectedResources": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "DetectedResources contain the resources related to the backing services which contributed binding data",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.DetectedResource"),
+									},
+								},
+							},
+						},
+					},
+					"services": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Services contain the resolution state of each backing service",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.ServiceStatus"),
+									},
+								},
+							},
+						},
+					},
+					"effective": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Effective contains the settings the ServiceBinding is bound with: the ones it declares, then the namespace defaults, then the operator defaults",
+							Ref:         ref("github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.EffectiveSettings"),
+						},
+					},
 				},
 				Required: []string{"conditions", "secret"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/openshift/custom-resource-status/conditions/v1.Condition", "github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.BoundApplication"},
+			"github.com/openshift/custom-resource-status/conditions/v1.Condition", "github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.AnnotationError", "github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.BoundApplication", "github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.DetectedResource", "github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.EffectiveSettings", "github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1.ServiceStatus"},
 	}
 }
@@ -0,0 +1,345 @@
+// Package config holds the operator configuration, read from the operator environment variables and
+// the OperatorConfig resource.
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/log"
+)
+
+const (
+	// LeaderForLife elects a leader for the lifetime of its pod.
+	LeaderForLife = "leader-for-life"
+	// LeaderWithLease elects a leader holding a renewed lease.
+	LeaderWithLease = "leader-with-lease"
+)
+
+const (
+	// InfoLevel logs warnings and informational messages.
+	InfoLevel = "info"
+	// DebugLevel logs debug messages as well.
+	DebugLevel = "debug"
+	// TraceLevel logs every message.
+	TraceLevel = "trace"
+)
+
+// CachedReadsGate reads services and their related resources from the informer cache instead of the
+// API server.
+const CachedReadsGate = "CachedReads"
+
+// defaultFeatureGates are the known feature gates, and whether they're enabled by default.
+var defaultFeatureGates = map[string]bool{
+	CachedReadsGate: true,
+}
+
+// LeaderElection configures the election of the active operator replica.
+type LeaderElection struct {
+	Enabled   bool
+	Mode      string
+	Namespace string
+}
+
+// Sharding configures the sharding of namespaces across the operator replicas.
+type Sharding struct {
+	Enabled bool
+	// LeaseDuration is how long a replica keeps its shard without renewing its lease.
+	LeaseDuration time.Duration
+}
+
+// RetryBackoff configures the exponential backoff between retries of a failed reconciliation.
+type RetryBackoff struct {
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	// JitterPercent is the upper bound of the random delay added to each retry, as a percentage of
+	// the delay.
+	JitterPercent int32
+}
+
+// Config is the operator configuration.
+type Config struct {
+	// WatchNamespaces are the namespaces watched by the operator; empty when all namespaces are.
+	WatchNamespaces         []string
+	LeaderElection          LeaderElection
+	Sharding                Sharding
+	MaxConcurrentReconciles int
+	RetryBackoff            RetryBackoff
+	// LogLevel caps the verbosity of the operator logs; empty when it follows the --zap-level flag.
+	LogLevel string
+	// RequeueAfter is the delay, in seconds, before reconciling again a ServiceBinding whose
+	// application isn't found.
+	RequeueAfter          int64
+	DefaultMountPath      string
+	DefaultContainersPath string
+	// DebugAddress is the address debug endpoints are served at; empty when they're disabled.
+	DebugAddress string
+	FeatureGates map[string]bool
+}
+
+// Default returns the default configuration.
+func Default() Config {
+	gates := make(map[string]bool, len(defaultFeatureGates))
+	for gate, enabled := range defaultFeatureGates {
+		gates[gate] = enabled
+	}
+	return Config{
+		WatchNamespaces:         []string{},
+		LeaderElection:          LeaderElection{Enabled: true, Mode: LeaderForLife},
+		Sharding:                Sharding{LeaseDuration: 30 * time.Second},
+		MaxConcurrentReconciles: 1,
+		RetryBackoff: RetryBackoff{
+			InitialDelay:  500 * time.Millisecond,
+			MaxDelay:      5 * time.Minute,
+			JitterPercent: 20,
+		},
+		RequeueAfter:          45,
+		DefaultMountPath:      "/var/data",
+		DefaultContainersPath: "spec.template.spec.containers",
+		FeatureGates:          gates,
+	}
+}
+
+// FromEnv returns the default configuration overridden by the operator environment variables.
+func FromEnv() Config {
+	c := Default()
+	c.WatchNamespaces = ParseNamespaces(os.Getenv("WATCH_NAMESPACE"))
+	c.LeaderElection.Enabled = os.Getenv("SERVICE_BINDING_OPERATOR_DISABLE_ELECTION") == ""
+	if os.Getenv("SERVICE_BINDING_OPERATOR_LEADER_ELECTION_OPTION") == LeaderWithLease {
+		c.LeaderElection.Mode = LeaderWithLease
+	}
+	c.LeaderElection.Namespace = os.Getenv("SERVICE_BINDING_OPERATOR_LEADER_ELECTION_NAMESPACE")
+	c.Sharding.Enabled = os.Getenv("SERVICE_BINDING_OPERATOR_SHARDING") == "true"
+	c.DebugAddress = os.Getenv("SERVICE_BINDING_OPERATOR_DEBUG_ADDRESS")
+	if n, err := strconv.Atoi(os.Getenv("SERVICE_BINDING_OPERATOR_MAX_CONCURRENT_RECONCILES")); err == nil && n > 0 {
+		c.MaxConcurrentReconciles = n
+	}
+	return c
+}
+
+// ParseNamespaces returns the namespaces in the given comma-separated list, ignoring blanks and
+// duplicates.
+func ParseNamespaces(value string) []string {
+	namespaces := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, ns := range strings.Split(value, ",") {
+		ns = strings.TrimSpace(ns)
+		if ns == "" || seen[ns] {
+			continue
+		}
+		seen[ns] = true
+		namespaces = append(namespaces, ns)
+	}
+	return namespaces
+}
+
+// Merge returns the configuration overridden by the fields set in spec, or an error when spec is
+// invalid.
+func (c Config) Merge(spec v1alpha1.OperatorConfigSpec) (Config, error) {
+	merged := c
+	if len(spec.WatchNamespaces) > 0 {
+		merged.WatchNamespaces = ParseNamespaces(strings.Join(spec.WatchNamespaces, ","))
+	}
+	if le := spec.LeaderElection; le != nil {
+		if le.Enabled != nil {
+			merged.LeaderElection.Enabled = *le.Enabled
+		}
+		switch le.Mode {
+		case "":
+		case LeaderForLife, LeaderWithLease:
+			merged.LeaderElection.Mode = le.Mode
+		default:
+			return c, fmt.Errorf("unknown leader election mode %q", le.Mode)
+		}
+		if le.Namespace != "" {
+			merged.LeaderElection.Namespace = le.Namespace
+		}
+	}
+	if sharding := spec.Sharding; sharding != nil {
+		if sharding.Enabled != nil {
+			merged.Sharding.Enabled = *sharding.Enabled
+		}
+		if sharding.LeaseDurationSeconds < 0 {
+			return c, fmt.Errorf("invalid sharding.leaseDurationSeconds %d", sharding.LeaseDurationSeconds)
+		} else if sharding.LeaseDurationSeconds > 0 {
+			merged.Sharding.LeaseDuration = time.Duration(sharding.LeaseDurationSeconds) * time.Second
+		}
+	}
+	if spec.MaxConcurrentReconciles < 0 {
+		return c, fmt.Errorf("invalid maxConcurrentReconciles %d", spec.MaxConcurrentReconciles)
+	} else if spec.MaxConcurrentReconciles > 0 {
+		merged.MaxConcurrentReconciles = spec.MaxConcurrentReconciles
+	}
+	if backoff := spec.RetryBackoff; backoff != nil {
+		if backoff.InitialDelay != nil {
+			if backoff.InitialDelay.Duration <= 0 {
+				return c, fmt.Errorf("invalid retryBackoff.initialDelay %s", backoff.InitialDelay.Duration)
+			}
+			merged.RetryBackoff.InitialDelay = backoff.InitialDelay.Duration
+		}
+		if backoff.MaxDelay != nil {
+			merged.RetryBackoff.MaxDelay = backoff.MaxDelay.Duration
+		}
+		if merged.RetryBackoff.MaxDelay < merged.RetryBackoff.InitialDelay {
+			return c, fmt.Errorf("retryBackoff.maxDelay %s is lower than the initial delay %s",
+				merged.RetryBackoff.MaxDelay, merged.RetryBackoff.InitialDelay)
+		}
+		if backoff.JitterPercent != nil {
+			if *backoff.JitterPercent < 0 || *backoff.JitterPercent > 100 {
+				return c, fmt.Errorf("invalid retryBackoff.jitterPercent %d", *backoff.JitterPercent)
+			}
+			merged.RetryBackoff.JitterPercent = *backoff.JitterPercent
+		}
+	}
+	switch spec.LogLevel {
+	case "":
+	case InfoLevel, DebugLevel, TraceLevel:
+		merged.LogLevel = spec.LogLevel
+	default:
+		return c, fmt.Errorf("unknown log level %q", spec.LogLevel)
+	}
+	if spec.RequeueAfterSeconds < 0 {
+		return c, fmt.Errorf("invalid requeueAfterSeconds %d", spec.RequeueAfterSeconds)
+	} else if spec.RequeueAfterSeconds > 0 {
+		merged.RequeueAfter = spec.RequeueAfterSeconds
+	}
+	if spec.DefaultMountPath != "" {
+		merged.DefaultMountPath = spec.DefaultMountPath
+	}
+	if spec.DefaultContainersPath != "" {
+		merged.DefaultContainersPath = spec.DefaultContainersPath
+	}
+	if spec.DebugAddress != "" {
+		merged.DebugAddress = spec.DebugAddress
+	}
+	if len(spec.FeatureGates) > 0 {
+		merged.FeatureGates = make(map[string]bool, len(c.FeatureGates))
+		for gate, enabled := range c.FeatureGates {
+			merged.FeatureGates[gate] = enabled
+		}
+		for gate, enabled := range spec.FeatureGates {
+			if _, ok := defaultFeatureGates[gate]; !ok {
+				return c, fmt.Errorf("unknown feature gate %q", gate)
+			}
+			merged.FeatureGates[gate] = enabled
+		}
+	}
+	return merged, nil
+}
+
+// Reloadable returns the configuration with the settings applied without a restart taken from
+// other: log level, retry backoff, requeue delay, default paths and feature gates.
+func (c Config) Reloadable(other Config) Config {
+	reloaded := c
+	reloaded.RetryBackoff = other.RetryBackoff
+	reloaded.LogLevel = other.LogLevel
+	reloaded.RequeueAfter = other.RequeueAfter
+	reloaded.DefaultMountPath = other.DefaultMountPath
+	reloaded.DefaultContainersPath = other.DefaultContainersPath
+	reloaded.FeatureGates = other.FeatureGates
+	return reloaded
+}
+
+// RestartRequired returns the settings applied on restart which differ in other.
+func (c Config) RestartRequired(other Config) []string {
+	var fields []string
+	if !reflect.DeepEqual(c.WatchNamespaces, other.WatchNamespaces) {
+		fields = append(fields, "watchNamespaces")
+	}
+	if c.LeaderElection != other.LeaderElection {
+		fields = append(fields, "leaderElection")
+	}
+	if c.Sharding != other.Sharding {
+		fields = append(fields, "sharding")
+	}
+	if c.MaxConcurrentReconciles != other.MaxConcurrentReconciles {
+		fields = append(fields, "maxConcurrentReconciles")
+	}
+	if c.DebugAddress != other.DebugAddress {
+		fields = append(fields, "debugAddress")
+	}
+	return fields
+}
+
+// FeatureEnabled returns whether the given feature gate is enabled.
+func (c Config) FeatureEnabled(gate string) bool {
+	return c.FeatureGates[gate]
+}
+
+// ToSpec returns the configuration as an OperatorConfigSpec.
+func (c Config) ToSpec() *v1alpha1.OperatorConfigSpec {
+	enabled := c.LeaderElection.Enabled
+	sharded := c.Sharding.Enabled
+	gates := make(map[string]bool, len(c.FeatureGates))
+	for gate, enabled := range c.FeatureGates {
+		gates[gate] = enabled
+	}
+	namespaces := append([]string{}, c.WatchNamespaces...)
+	sort.Strings(namespaces)
+	jitterPercent := c.RetryBackoff.JitterPercent
+	return &v1alpha1.OperatorConfigSpec{
+		WatchNamespaces: namespaces,
+		LeaderElection: &v1alpha1.LeaderElectionConfig{
+			Enabled:   &enabled,
+			Mode:      c.LeaderElection.Mode,
+			Namespace: c.LeaderElection.Namespace,
+		},
+		Sharding: &v1alpha1.ShardingConfig{
+			Enabled:              &sharded,
+			LeaseDurationSeconds: int32(c.Sharding.LeaseDuration / time.Second),
+		},
+		MaxConcurrentReconciles: c.MaxConcurrentReconciles,
+		RetryBackoff: &v1alpha1.RetryBackoffConfig{
+			InitialDelay:  &metav1.Duration{Duration: c.RetryBackoff.InitialDelay},
+			MaxDelay:      &metav1.Duration{Duration: c.RetryBackoff.MaxDelay},
+			JitterPercent: &jitterPercent,
+		},
+		LogLevel:              c.LogLevel,
+		RequeueAfterSeconds:   c.RequeueAfter,
+		DefaultMountPath:      c.DefaultMountPath,
+		DefaultContainersPath: c.DefaultContainersPath,
+		DebugAddress:          c.DebugAddress,
+		FeatureGates:          gates,
+	}
+}
+
+// logLevels maps log levels to the verbosity of the operator logs.
+var logLevels = map[string]int{
+	"":         log.TraceVerbosity,
+	InfoLevel:  log.InfoVerbosity,
+	DebugLevel: log.DebugVerbosity,
+	TraceLevel: log.TraceVerbosity,
+}
+
+var (
+	mu      sync.RWMutex
+	current *Config
+)
+
+// Get returns the configuration in effect; the configuration given by the environment variables
+// until one is set.
+func Get() Config {
+	mu.RLock()
+	defer mu.RUnlock()
+	if current == nil {
+		return FromEnv()
+	}
+	return *current
+}
+
+// Set puts the given configuration in effect.
+func Set(c Config) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = &c
+	log.SetVerbosity(logLevels[c.LogLevel])
+}
@@ -0,0 +1,185 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+)
+
+// setEnv sets the given environment variables, and returns a function restoring them.
+func setEnv(t *testing.T, env map[string]string) func() {
+	var restore []func()
+	for name, value := range env {
+		name := name
+		previous, ok := os.LookupEnv(name)
+		require.NoError(t, os.Setenv(name, value))
+		restore = append(restore, func() {
+			if ok {
+				os.Setenv(name, previous)
+			} else {
+				os.Unsetenv(name)
+			}
+		})
+	}
+	return func() {
+		for _, f := range restore {
+			f()
+		}
+	}
+}
+
+func TestParseNamespaces(t *testing.T) {
+	require.Empty(t, ParseNamespaces(""))
+	require.Equal(t, []string{"single"}, ParseNamespaces("single"))
+	require.Equal(t, []string{"first", "second"}, ParseNamespaces(" first, ,second,first "))
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		for _, name := range []string{
+			"WATCH_NAMESPACE",
+			"SERVICE_BINDING_OPERATOR_DISABLE_ELECTION",
+			"SERVICE_BINDING_OPERATOR_LEADER_ELECTION_OPTION",
+			"SERVICE_BINDING_OPERATOR_LEADER_ELECTION_NAMESPACE",
+			"SERVICE_BINDING_OPERATOR_MAX_CONCURRENT_RECONCILES",
+			"SERVICE_BINDING_OPERATOR_SHARDING",
+			"SERVICE_BINDING_OPERATOR_DEBUG_ADDRESS",
+		} {
+			previous, ok := os.LookupEnv(name)
+			require.NoError(t, os.Unsetenv(name))
+			if ok {
+				defer os.Setenv(name, previous)
+			}
+		}
+		require.Equal(t, Default(), FromEnv())
+	})
+
+	t.Run("environment variables", func(t *testing.T) {
+		defer setEnv(t, map[string]string{
+			"WATCH_NAMESPACE": "tenant-a,tenant-b",
+			"SERVICE_BINDING_OPERATOR_DISABLE_ELECTION":          "true",
+			"SERVICE_BINDING_OPERATOR_LEADER_ELECTION_OPTION":    LeaderWithLease,
+			"SERVICE_BINDING_OPERATOR_LEADER_ELECTION_NAMESPACE": "leases",
+			"SERVICE_BINDING_OPERATOR_MAX_CONCURRENT_RECONCILES": "3",
+			"SERVICE_BINDING_OPERATOR_SHARDING":                  "true",
+			"SERVICE_BINDING_OPERATOR_DEBUG_ADDRESS":             ":8082",
+		})()
+		c := FromEnv()
+		require.Equal(t, []string{"tenant-a", "tenant-b"}, c.WatchNamespaces)
+		require.Equal(t, LeaderElection{Enabled: false, Mode: LeaderWithLease, Namespace: "leases"}, c.LeaderElection)
+		require.Equal(t, 3, c.MaxConcurrentReconciles)
+		require.Equal(t, Sharding{Enabled: true, LeaseDuration: 30 * time.Second}, c.Sharding)
+		require.Equal(t, ":8082", c.DebugAddress)
+		require.Equal(t, int64(45), c.RequeueAfter)
+	})
+}
+
+func TestMerge(t *testing.T) {
+	disabled := false
+
+	t.Run("set fields override", func(t *testing.T) {
+		c, err := Default().Merge(v1alpha1.OperatorConfigSpec{
+			WatchNamespaces:         []string{"tenant-a"},
+			LeaderElection:          &v1alpha1.LeaderElectionConfig{Enabled: &disabled},
+			MaxConcurrentReconciles: 4,
+			LogLevel:                DebugLevel,
+			RequeueAfterSeconds:     10,
+			DefaultMountPath:        "/bindings",
+			FeatureGates:            map[string]bool{CachedReadsGate: false},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"tenant-a"}, c.WatchNamespaces)
+		require.Equal(t, LeaderElection{Enabled: false, Mode: LeaderForLife}, c.LeaderElection)
+		require.Equal(t, 4, c.MaxConcurrentReconciles)
+		require.Equal(t, DebugLevel, c.LogLevel)
+		require.Equal(t, int64(10), c.RequeueAfter)
+		require.Equal(t, "/bindings", c.DefaultMountPath)
+		require.Equal(t, "spec.template.spec.containers", c.DefaultContainersPath)
+		require.False(t, c.FeatureEnabled(CachedReadsGate))
+		require.True(t, Default().FeatureEnabled(CachedReadsGate), "the defaults are left unchanged")
+	})
+
+	t.Run("sharding", func(t *testing.T) {
+		enabled := true
+		c, err := Default().Merge(v1alpha1.OperatorConfigSpec{
+			Sharding: &v1alpha1.ShardingConfig{Enabled: &enabled, LeaseDurationSeconds: 15},
+		})
+		require.NoError(t, err)
+		require.Equal(t, Sharding{Enabled: true, LeaseDuration: 15 * time.Second}, c.Sharding)
+		require.Equal(t, []string{"sharding"}, Default().RestartRequired(c))
+	})
+
+	t.Run("retry backoff", func(t *testing.T) {
+		jitter := int32(0)
+		c, err := Default().Merge(v1alpha1.OperatorConfigSpec{
+			RetryBackoff: &v1alpha1.RetryBackoffConfig{
+				InitialDelay:  &metav1.Duration{Duration: time.Second},
+				JitterPercent: &jitter,
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, RetryBackoff{InitialDelay: time.Second, MaxDelay: 5 * time.Minute}, c.RetryBackoff)
+	})
+
+	for name, spec := range map[string]v1alpha1.OperatorConfigSpec{
+		"unknown log level":       {LogLevel: "verbose"},
+		"unknown election mode":   {LeaderElection: &v1alpha1.LeaderElectionConfig{Mode: "round-robin"}},
+		"negative concurrency":    {MaxConcurrentReconciles: -1},
+		"negative requeue":        {RequeueAfterSeconds: -1},
+		"negative lease duration": {Sharding: &v1alpha1.ShardingConfig{LeaseDurationSeconds: -1}},
+		"unknown feature gate":    {FeatureGates: map[string]bool{"Unknown": true}},
+		"zero initial delay": {RetryBackoff: &v1alpha1.RetryBackoffConfig{
+			InitialDelay: &metav1.Duration{},
+		}},
+		"max delay lower than initial delay": {RetryBackoff: &v1alpha1.RetryBackoffConfig{
+			InitialDelay: &metav1.Duration{Duration: time.Minute},
+			MaxDelay:     &metav1.Duration{Duration: time.Second},
+		}},
+		"jitter above 100 percent": {RetryBackoff: &v1alpha1.RetryBackoffConfig{
+			JitterPercent: func() *int32 { j := int32(101); return &j }(),
+		}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c, err := Default().Merge(spec)
+			require.Error(t, err)
+			require.Equal(t, Default(), c)
+		})
+	}
+}
+
+func TestReloadable(t *testing.T) {
+	startup := Default()
+	changed, err := startup.Merge(v1alpha1.OperatorConfigSpec{
+		WatchNamespaces:         []string{"tenant-a"},
+		MaxConcurrentReconciles: 2,
+		LogLevel:                TraceLevel,
+		RequeueAfterSeconds:     5,
+	})
+	require.NoError(t, err)
+
+	reloaded := startup.Reloadable(changed)
+	require.Empty(t, reloaded.WatchNamespaces)
+	require.Equal(t, 1, reloaded.MaxConcurrentReconciles)
+	require.Equal(t, TraceLevel, reloaded.LogLevel)
+	require.Equal(t, int64(5), reloaded.RequeueAfter)
+
+	require.Equal(t, []string{"watchNamespaces", "maxConcurrentReconciles"}, startup.RestartRequired(changed))
+	require.Empty(t, startup.RestartRequired(reloaded))
+}
+
+func TestToSpec(t *testing.T) {
+	c, err := Default().Merge(v1alpha1.OperatorConfigSpec{WatchNamespaces: []string{"b", "a"}, LogLevel: InfoLevel})
+	require.NoError(t, err)
+	merged, err := Default().Merge(*c.ToSpec())
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, merged.WatchNamespaces)
+	require.Equal(t, InfoLevel, merged.LogLevel)
+	require.Equal(t, c.LeaderElection, merged.LeaderElection)
+	require.Equal(t, c.RetryBackoff, merged.RetryBackoff)
+	require.Equal(t, c.Sharding, merged.Sharding)
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/log"
+)
+
+// ResourceName is the name of the OperatorConfig resource read by the operator, in its namespace.
+const ResourceName = "service-binding-operator"
+
+const (
+	// AppliedCondition tells whether the configuration is in effect.
+	AppliedCondition conditionsv1.ConditionType = "Applied"
+	// RestartRequiredCondition tells whether settings applied on restart differ from the ones the
+	// operator started with.
+	RestartRequiredCondition conditionsv1.ConditionType = "RestartRequired"
+
+	// ConfigurationAppliedReason is used when the configuration is in effect.
+	ConfigurationAppliedReason = "ConfigurationApplied"
+	// InvalidConfigurationReason is used when the configuration is rejected.
+	InvalidConfigurationReason = "InvalidConfiguration"
+	// StartupSettingsChangedReason is used when settings applied on restart have changed.
+	StartupSettingsChangedReason = "StartupSettingsChanged"
+	// StartupSettingsAppliedReason is used when the operator runs with the settings applied on
+	// restart.
+	StartupSettingsAppliedReason = "StartupSettingsApplied"
+)
+
+var (
+	configLog = log.NewLog("config")
+
+	operatorConfigsResource = v1alpha1.SchemeGroupVersion.WithResource("operatorconfigs")
+)
+
+// Load returns the configuration given by the environment variables, overridden by the OperatorConfig
+// resource in the given namespace when there's one.
+func Load(client dynamic.Interface, ns string) (Config, error) {
+	env := FromEnv()
+	u, err := client.Resource(operatorConfigsResource).Namespace(ns).Get(ResourceName, metav1.GetOptions{})
+	if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return env, nil
+	} else if err != nil {
+		return env, err
+	}
+	operatorConfig, err := toOperatorConfig(u)
+	if err != nil {
+		return env, err
+	}
+	return env.Merge(operatorConfig.Spec)
+}
+
+// toOperatorConfig converts the given object to an OperatorConfig.
+func toOperatorConfig(u *unstructured.Unstructured) (*v1alpha1.OperatorConfig, error) {
+	operatorConfig := &v1alpha1.OperatorConfig{}
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, operatorConfig)
+	return operatorConfig, err
+}
+
+// Reloader puts in effect the changes of the OperatorConfig resource which are applied without a
+// restart, and reports the configuration in effect in the resource status.
+type Reloader struct {
+	client dynamic.Interface
+	ns     string
+	// startup is the configuration the operator started with.
+	startup Config
+}
+
+// NewReloader returns a Reloader of the OperatorConfig resource in the given namespace; it's meant
+// to be added to the manager.
+func NewReloader(client dynamic.Interface, ns string, startup Config) *Reloader {
+	return &Reloader{client: client, ns: ns, startup: startup}
+}
+
+// Start watches the OperatorConfig resource until the given channel is closed.
+func (r *Reloader) Start(stop <-chan struct{}) error {
+	resourceClient := r.client.Resource(operatorConfigsResource).Namespace(r.ns)
+	selector := fields.OneTermEqualSelector("metadata.name", ResourceName).String()
+	lw := &toolscache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			opts.FieldSelector = selector
+			return resourceClient.List(opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			opts.FieldSelector = selector
+			return resourceClient.Watch(opts)
+		},
+	}
+	informer := toolscache.NewSharedIndexInformer(lw, &unstructured.Unstructured{}, 0, toolscache.Indexers{})
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: r.onChange,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			// status updates, including the reloader's own, leave the generation unchanged
+			if oldObj.(*unstructured.Unstructured).GetGeneration() != newObj.(*unstructured.Unstructured).GetGeneration() {
+				r.onChange(newObj)
+			}
+		},
+		DeleteFunc: func(interface{}) {
+			configLog.Info("Operator configuration deleted, reverting to the environment configuration")
+			Set(Get().Reloadable(FromEnv()))
+		},
+	})
+	informer.Run(stop)
+	return nil
+}
+
+// onChange applies the given OperatorConfig, and updates its status.
+func (r *Reloader) onChange(obj interface{}) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	operatorConfig, err := toOperatorConfig(u)
+	if err != nil {
+		configLog.Error(err, "on converting the operator configuration")
+		return
+	}
+	r.apply(operatorConfig)
+
+	status, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&operatorConfig.Status)
+	if err != nil {
+		configLog.Error(err, "on converting the operator configuration status")
+		return
+	}
+	u = u.DeepCopy()
+	u.Object["status"] = status
+	_, err = r.client.Resource(operatorConfigsResource).Namespace(r.ns).UpdateStatus(u, metav1.UpdateOptions{})
+	if err != nil {
+		configLog.Error(err, "on updating the operator configuration status")
+	}
+}
+
+// apply puts in effect the settings of the given OperatorConfig applied without a restart, and
+// records the outcome in its status.
+func (r *Reloader) apply(operatorConfig *v1alpha1.OperatorConfig) {
+	status := &operatorConfig.Status
+	status.ObservedGeneration = operatorConfig.Generation
+
+	desired, err := FromEnv().Merge(operatorConfig.Spec)
+	if err != nil {
+		configLog.Error(err, "Operator configuration rejected")
+		conditionsv1.SetStatusCondition(&status.Conditions, conditionsv1.Condition{
+			Type:    AppliedCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  InvalidConfigurationReason,
+			Message: err.Error(),
+		})
+		status.Applied = Get().ToSpec()
+		return
+	}
+
+	Set(Get().Reloadable(desired))
+	configLog.Info("Operator configuration applied", "Generation", operatorConfig.Generation)
+	conditionsv1.SetStatusCondition(&status.Conditions, conditionsv1.Condition{
+		Type:   AppliedCondition,
+		Status: corev1.ConditionTrue,
+		Reason: ConfigurationAppliedReason,
+	})
+	if fields := r.startup.RestartRequired(desired); len(fields) > 0 {
+		conditionsv1.SetStatusCondition(&status.Conditions, conditionsv1.Condition{
+			Type:    RestartRequiredCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  StartupSettingsChangedReason,
+			Message: fmt.Sprintf("restart the operator to apply %s", strings.Join(fields, ", ")),
+		})
+	} else {
+		conditionsv1.SetStatusCondition(&status.Conditions, conditionsv1.Condition{
+			Type:   RestartRequiredCondition,
+			Status: corev1.ConditionFalse,
+			Reason: StartupSettingsAppliedReason,
+		})
+	}
+	status.Applied = Get().ToSpec()
+}
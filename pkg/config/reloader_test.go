@@ -0,0 +1,110 @@
+package config
+
+import (
+	"testing"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+)
+
+// newOperatorConfig returns an OperatorConfig with the given spec, as an unstructured object.
+func newOperatorConfig(t *testing.T, ns string, generation int64, spec v1alpha1.OperatorConfigSpec) *unstructured.Unstructured {
+	operatorConfig := &v1alpha1.OperatorConfig{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "OperatorConfig"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: ResourceName, Generation: generation},
+		Spec:       spec,
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(operatorConfig)
+	require.NoError(t, err)
+	return &unstructured.Unstructured{Object: obj}
+}
+
+func TestLoad(t *testing.T) {
+	ns := "operator"
+
+	t.Run("without OperatorConfig", func(t *testing.T) {
+		client := fake.NewSimpleDynamicClient(runtime.NewScheme())
+		c, err := Load(client, ns)
+		require.NoError(t, err)
+		require.Equal(t, FromEnv(), c)
+	})
+
+	t.Run("with OperatorConfig", func(t *testing.T) {
+		u := newOperatorConfig(t, ns, 1, v1alpha1.OperatorConfigSpec{MaxConcurrentReconciles: 3})
+		client := fake.NewSimpleDynamicClient(runtime.NewScheme(), u)
+		c, err := Load(client, ns)
+		require.NoError(t, err)
+		require.Equal(t, 3, c.MaxConcurrentReconciles)
+	})
+}
+
+func TestReloader(t *testing.T) {
+	ns := "operator"
+	defer Set(Get())
+
+	startup := FromEnv()
+	Set(startup)
+
+	// readStatus returns the status of the OperatorConfig
+	readStatus := func(r *Reloader) v1alpha1.OperatorConfigStatus {
+		u, err := r.client.Resource(operatorConfigsResource).Namespace(ns).Get(ResourceName, metav1.GetOptions{})
+		require.NoError(t, err)
+		operatorConfig, err := toOperatorConfig(u)
+		require.NoError(t, err)
+		return operatorConfig.Status
+	}
+
+	t.Run("settings applied without restart", func(t *testing.T) {
+		u := newOperatorConfig(t, ns, 2, v1alpha1.OperatorConfigSpec{RequeueAfterSeconds: 5, DefaultMountPath: "/bindings"})
+		r := NewReloader(fake.NewSimpleDynamicClient(runtime.NewScheme(), u), ns, startup)
+		r.onChange(u)
+
+		require.Equal(t, int64(5), Get().RequeueAfter)
+		require.Equal(t, "/bindings", Get().DefaultMountPath)
+
+		status := readStatus(r)
+		require.Equal(t, int64(2), status.ObservedGeneration)
+		require.Equal(t, int64(5), status.Applied.RequeueAfterSeconds)
+		require.True(t, conditionsv1.IsStatusConditionTrue(status.Conditions, AppliedCondition))
+		require.True(t, conditionsv1.IsStatusConditionFalse(status.Conditions, RestartRequiredCondition))
+	})
+
+	t.Run("settings applied on restart", func(t *testing.T) {
+		u := newOperatorConfig(t, ns, 3, v1alpha1.OperatorConfigSpec{MaxConcurrentReconciles: startup.MaxConcurrentReconciles + 1})
+		r := NewReloader(fake.NewSimpleDynamicClient(runtime.NewScheme(), u), ns, startup)
+		r.onChange(u)
+
+		require.Equal(t, startup.MaxConcurrentReconciles, Get().MaxConcurrentReconciles)
+		require.Equal(t, startup.RequeueAfter, Get().RequeueAfter, "unset settings revert to the environment")
+
+		status := readStatus(r)
+		require.Equal(t, startup.MaxConcurrentReconciles, status.Applied.MaxConcurrentReconciles)
+		require.True(t, conditionsv1.IsStatusConditionTrue(status.Conditions, AppliedCondition))
+		restart := conditionsv1.FindStatusCondition(status.Conditions, RestartRequiredCondition)
+		require.NotNil(t, restart)
+		require.Equal(t, corev1.ConditionTrue, restart.Status)
+		require.Equal(t, StartupSettingsChangedReason, restart.Reason)
+		require.Contains(t, restart.Message, "maxConcurrentReconciles")
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		Set(startup)
+		u := newOperatorConfig(t, ns, 4, v1alpha1.OperatorConfigSpec{LogLevel: "verbose", RequeueAfterSeconds: 5})
+		r := NewReloader(fake.NewSimpleDynamicClient(runtime.NewScheme(), u), ns, startup)
+		r.onChange(u)
+
+		require.Equal(t, startup, Get())
+		status := readStatus(r)
+		applied := conditionsv1.FindStatusCondition(status.Conditions, AppliedCondition)
+		require.NotNil(t, applied)
+		require.Equal(t, corev1.ConditionFalse, applied.Status)
+		require.Equal(t, InvalidConfigurationReason, applied.Reason)
+	})
+}
@@ -21,9 +21,12 @@ var (
 
 // updateUnstructuredObj generic call to update the unstructured resource informed. It can return
 // error when API update call does.
-func updateUnstructuredObj(client dynamic.Interface, obj *unstructured.Unstructured) error {
+func updateUnstructuredObj(client dynamic.Interface, obj *unstructured.Unstructured, restMapper meta.RESTMapper) error {
 	gvk := obj.GroupVersionKind()
-	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+	mapping, err := restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return err
+	}
 	opts := metav1.UpdateOptions{}
 
 	log := annotationsLog.WithValues(
@@ -35,7 +38,7 @@ func updateUnstructuredObj(client dynamic.Interface, obj *unstructured.Unstructu
 	)
 	log.Debug("Updating resource annotations...")
 
-	_, err := client.Resource(gvr).Namespace(obj.GetNamespace()).Update(obj, opts)
+	_, err = client.Resource(mapping.Resource).Namespace(obj.GetNamespace()).Update(obj, opts)
 	if err != nil {
 		log.Error(err, "unable to set/update annotations in object")
 	}
@@ -71,7 +74,11 @@ func removeSBRAnnotations(obj *unstructured.Unstructured) *unstructured.Unstruct
 
 // removeAndUpdateSBRAnnotations removes SBR related annotations from all the objects and updates them using
 // the given client.
-func removeAndUpdateSBRAnnotations(client dynamic.Interface, objs []*unstructured.Unstructured) error {
+func removeAndUpdateSBRAnnotations(
+	client dynamic.Interface,
+	objs []*unstructured.Unstructured,
+	restMapper meta.RESTMapper,
+) error {
 	for _, obj := range objs {
 		newObj := removeSBRAnnotations(obj)
 		equal, err := nestedUnstructuredComparison(obj, newObj, []string{"metadata", "annotations"}...)
@@ -79,7 +86,7 @@ func removeAndUpdateSBRAnnotations(client dynamic.Interface, objs []*unstructure
 			return err
 		}
 		if !equal.Success {
-			if err := updateUnstructuredObj(client, newObj); err != nil {
+			if err := updateUnstructuredObj(client, newObj, restMapper); err != nil {
 				return err
 			}
 		}
@@ -0,0 +1,201 @@
+package servicebinding
+
+import (
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/yaml"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/log"
+)
+
+const (
+	// bindableResourcesConfigMapName is the ConfigMap, in the operator namespace, declaring bindable
+	// resources in addition to the built-in ones.
+	bindableResourcesConfigMapName = "service-binding-operator-bindable-resources"
+	// bindableResourcesKey is the ConfigMap data key holding the list of bindable resources.
+	bindableResourcesKey = "bindableResources"
+)
+
+// bindableResourceConfig is a bindable resource declared in the configuration ConfigMap.
+type bindableResourceConfig struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+	// Resource is the plural resource name, discovered through the RESTMapper when empty.
+	Resource   string `json:"resource,omitempty"`
+	InputPath  string `json:"inputPath"`
+	OutputPath string `json:"outputPath,omitempty"`
+}
+
+// parseBindableResources parses the bindable resources declared in the given YAML document.
+func parseBindableResources(data string, restMapper meta.RESTMapper) ([]bindableResource, error) {
+	var configs []bindableResourceConfig
+	if err := yaml.UnmarshalStrict([]byte(data), &configs); err != nil {
+		return nil, err
+	}
+
+	resources := make([]bindableResource, 0, len(configs))
+	for i, c := range configs {
+		if c.Version == "" || c.Kind == "" || c.InputPath == "" {
+			return nil, fmt.Errorf("bindable resource %d: version, kind and inputPath are required", i)
+		}
+		gvk := schema.GroupVersionKind{Group: c.Group, Version: c.Version, Kind: c.Kind}
+		gvr := gvk.GroupVersion().WithResource(c.Resource)
+		if c.Resource == "" {
+			mapping, err := restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+			if err != nil {
+				return nil, fmt.Errorf("bindable resource %d: %v", i, err)
+			}
+			gvr = mapping.Resource
+		}
+		resources = append(resources, bindableResource{
+			gvk:        gvk,
+			gvr:        gvr,
+			inputPath:  c.InputPath,
+			outputPath: c.OutputPath,
+		})
+	}
+	return resources, nil
+}
+
+// bindableResourceRegistry holds the resource types whose values are collected from the resources
+// owned by a service: the built-in defaults, merged with the ones declared in the configuration
+// ConfigMap.
+type bindableResourceRegistry struct {
+	mu         sync.RWMutex
+	defaults   []bindableResource
+	configured []bindableResource
+}
+
+// newBindableResourceRegistry returns a registry holding the given defaults.
+func newBindableResourceRegistry(defaults ...bindableResource) *bindableResourceRegistry {
+	return &bindableResourceRegistry{defaults: defaults}
+}
+
+// set replaces the configured bindable resources.
+func (r *bindableResourceRegistry) set(configured []bindableResource) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.configured = configured
+}
+
+// list returns the bindable resources; a configured entry replaces the default with the same GVK
+// and input path.
+func (r *bindableResourceRegistry) list() []bindableResource {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	resources := make([]bindableResource, 0, len(r.defaults)+len(r.configured))
+DEFAULTS:
+	for _, d := range r.defaults {
+		for _, c := range r.configured {
+			if c.gvk == d.gvk && c.inputPath == d.inputPath {
+				continue DEFAULTS
+			}
+		}
+		resources = append(resources, d)
+	}
+	return append(resources, r.configured...)
+}
+
+// resources returns the distinct GVRs of the bindable resources.
+func (r *bindableResourceRegistry) resources() []schema.GroupVersionResource {
+	seen := make(map[schema.GroupVersionResource]bool)
+	gvrs := make([]schema.GroupVersionResource, 0)
+	for _, br := range r.list() {
+		if !seen[br.gvr] {
+			seen[br.gvr] = true
+			gvrs = append(gvrs, br.gvr)
+		}
+	}
+	return gvrs
+}
+
+// kinds returns the distinct GVKs of the bindable resources.
+func (r *bindableResourceRegistry) kinds() []schema.GroupVersionKind {
+	seen := make(map[schema.GroupVersionKind]bool)
+	gvks := make([]schema.GroupVersionKind, 0)
+	for _, br := range r.list() {
+		if !seen[br.gvk] {
+			seen[br.gvk] = true
+			gvks = append(gvks, br.gvk)
+		}
+	}
+	return gvks
+}
+
+// bindableResourcesHandler updates a registry from the configuration ConfigMap events.
+type bindableResourcesHandler struct {
+	registry   *bindableResourceRegistry
+	restMapper meta.RESTMapper
+	// onChange is called after the registry is updated.
+	onChange func()
+	logger   *log.Log
+}
+
+var _ toolscache.ResourceEventHandler = (*bindableResourcesHandler)(nil)
+
+// update replaces the configured bindable resources with the ones declared in obj; the previous
+// configuration is kept when obj can't be parsed.
+func (h *bindableResourcesHandler) update(obj interface{}) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	data, _, err := unstructured.NestedString(u.Object, "data", bindableResourcesKey)
+	if err != nil {
+		h.logger.Error(err, "Reading bindable resources configuration")
+		return
+	}
+	configured, err := parseBindableResources(data, h.restMapper)
+	if err != nil {
+		h.logger.Error(err, "Parsing bindable resources configuration, keeping previous configuration")
+		return
+	}
+	h.registry.set(configured)
+	h.logger.Info("Bindable resources configuration loaded", "Configured.Amount", len(configured))
+	h.onChange()
+}
+
+func (h *bindableResourcesHandler) OnAdd(obj interface{}) {
+	h.update(obj)
+}
+
+func (h *bindableResourcesHandler) OnUpdate(_, newObj interface{}) {
+	h.update(newObj)
+}
+
+func (h *bindableResourcesHandler) OnDelete(interface{}) {
+	h.registry.set(nil)
+	h.logger.Info("Bindable resources configuration removed, using defaults")
+	h.onChange()
+}
+
+// newBindableResourcesInformer returns an informer on the configuration ConfigMap in the given
+// namespace.
+func newBindableResourcesInformer(client dynamic.Interface, ns string) toolscache.SharedIndexInformer {
+	resourceClient := client.Resource(corev1.SchemeGroupVersion.WithResource("configmaps")).Namespace(ns)
+	selector := fields.OneTermEqualSelector("metadata.name", bindableResourcesConfigMapName).String()
+	lw := &toolscache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			opts.FieldSelector = selector
+			return resourceClient.List(opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			opts.FieldSelector = selector
+			return resourceClient.Watch(opts)
+		},
+	}
+	return toolscache.NewSharedIndexInformer(lw, &unstructured.Unstructured{}, 0, toolscache.Indexers{})
+}
@@ -0,0 +1,188 @@
+package servicebinding
+
+import (
+	"testing"
+
+	pgv1alpha1 "github.com/operator-backing-service-samples/postgresql-operator/pkg/apis/postgresql/v1alpha1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/controller/servicebinding/binding"
+	"github.com/redhat-developer/service-binding-operator/pkg/log"
+	"github.com/redhat-developer/service-binding-operator/pkg/testutils"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+var (
+	serviceGVK = schema.GroupVersionKind{Version: "v1", Kind: "Service"}
+	ingressGVK = schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1beta1", Kind: "Ingress"}
+)
+
+func TestParseBindableResources(t *testing.T) {
+	restMapper := testutils.BuildTestRESTMapper()
+
+	t.Run("valid", func(t *testing.T) {
+		got, err := parseBindableResources(`
+- group: networking.k8s.io
+  version: v1beta1
+  kind: Ingress
+  resource: ingresses
+  inputPath: spec.rules.host
+  outputPath: hosts
+- version: v1
+  kind: Service
+  inputPath: spec.ports.port
+  outputPath: ports
+`, restMapper)
+		require.NoError(t, err)
+		require.Equal(t, []bindableResource{
+			{
+				gvk:        ingressGVK,
+				gvr:        ingressGVK.GroupVersion().WithResource("ingresses"),
+				inputPath:  "spec.rules.host",
+				outputPath: "hosts",
+			},
+			{
+				gvk:        serviceGVK,
+				gvr:        serviceGVK.GroupVersion().WithResource("services"),
+				inputPath:  "spec.ports.port",
+				outputPath: "ports",
+			},
+		}, got)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		got, err := parseBindableResources("", restMapper)
+		require.NoError(t, err)
+		require.Empty(t, got)
+	})
+
+	invalid := map[string]string{
+		"missing input path":       "- {version: v1, kind: Service}",
+		"unknown kind":             "- {group: example.com, version: v1, kind: Unknown, inputPath: spec}",
+		"unknown field":            "- {version: v1, kind: Service, inputPath: spec, path: spec}",
+		"not a list of resources":  "version: v1",
+		"malformed yaml":           "- {version",
+		"missing kind and version": "- {inputPath: spec}",
+	}
+	for name, data := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseBindableResources(data, restMapper)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestBindableResourceRegistry(t *testing.T) {
+	clusterIP := bindableResource{
+		gvk:        serviceGVK,
+		gvr:        serviceGVK.GroupVersion().WithResource("services"),
+		inputPath:  "spec.clusterIP",
+		outputPath: "clusterIP",
+	}
+	registry := newBindableResourceRegistry(clusterIP)
+	require.Equal(t, []bindableResource{clusterIP}, registry.list())
+
+	ports := clusterIP
+	ports.inputPath, ports.outputPath = "spec.ports.port", "ports"
+	ip := clusterIP
+	ip.outputPath = "ip"
+	registry.set([]bindableResource{ports, ip})
+
+	// the configured entry with the same input path replaces the default
+	require.Equal(t, []bindableResource{ports, ip}, registry.list())
+	require.Equal(t, []schema.GroupVersionResource{clusterIP.gvr}, registry.resources())
+	require.Equal(t, []schema.GroupVersionKind{serviceGVK}, registry.kinds())
+
+	registry.set(nil)
+	require.Equal(t, []bindableResource{clusterIP}, registry.list())
+}
+
+func TestBindableResourcesHandler(t *testing.T) {
+	registry := newBindableResourceRegistry(defaultBindableResources...)
+	changes := 0
+	h := &bindableResourcesHandler{
+		registry:   registry,
+		restMapper: testutils.BuildTestRESTMapper(),
+		onChange:   func() { changes++ },
+		logger:     log.NewLog("testBindableResourcesHandler"),
+	}
+	configMap := func(data string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+		u.SetName(bindableResourcesConfigMapName)
+		require.NoError(t, unstructured.SetNestedField(u.Object, data, "data", bindableResourcesKey))
+		return u
+	}
+
+	h.OnAdd(configMap("- {version: v1, kind: Service, inputPath: spec.ports.port, outputPath: ports}"))
+	require.Equal(t, 1, changes)
+	require.Len(t, registry.list(), len(defaultBindableResources)+1)
+
+	// invalid configuration is ignored, and the previous one is kept
+	h.OnUpdate(nil, configMap("- {kind: Service}"))
+	require.Equal(t, 1, changes)
+	require.Len(t, registry.list(), len(defaultBindableResources)+1)
+
+	h.OnDelete(configMap(""))
+	require.Equal(t, 2, changes)
+	require.Equal(t, defaultBindableResources, registry.list())
+}
+
+func TestFindOwnedResourcesCtxs_ConfiguredBindableResources(t *testing.T) {
+	ns := "bindable"
+	f := mocks.NewFake(t, ns)
+
+	cr := mocks.DatabaseCRMock(ns, "db")
+	f.S.AddKnownTypes(pgv1alpha1.SchemeGroupVersion, &pgv1alpha1.Database{})
+	f.AddMockResource(cr)
+	owner := metav1.OwnerReference{APIVersion: cr.APIVersion, Kind: cr.Kind, Name: cr.Name, UID: cr.UID}
+
+	svc := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"clusterIP": "10.0.0.1",
+			"ports": []interface{}{
+				map[string]interface{}{"name": "pg", "port": int64(5432)},
+			},
+		},
+	}}
+	svc.SetGroupVersionKind(serviceGVK)
+	svc.SetNamespace(ns)
+	svc.SetName("db-svc")
+	svc.SetOwnerReferences([]metav1.OwnerReference{owner})
+	f.AddMockResource(svc)
+
+	restMapper := testutils.BuildTestRESTMapper()
+	configured, err := parseBindableResources(
+		"- {version: v1, kind: Service, inputPath: spec.ports.port, outputPath: ports}", restMapper)
+	require.NoError(t, err)
+	bindableResources.set(configured)
+	defer bindableResources.set(nil)
+
+	got, err := findOwnedResourcesCtxs(
+		log.NewLog("testFindOwnedResourcesCtxs_configured"),
+		f.FakeDynClient(),
+		ns,
+		cr.GetName(),
+		cr.GetUID(),
+		cr.GroupVersionKind(),
+		nil,
+		nil,
+		restMapper,
+		nil,
+	)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, map[string]interface{}{"clusterIP": "10.0.0.1"}, got[0].envVars)
+	require.Equal(t, map[string]interface{}{"ports": []interface{}{int64(5432)}}, got[1].envVars)
+
+	// owned resources are tracked
+	require.Contains(t, got[1].references, binding.ObjectReference{
+		GroupVersionKind: serviceGVK,
+		Namespace:        ns,
+		Name:             "db-svc",
+	})
+}
@@ -18,10 +18,12 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/config"
 	"github.com/redhat-developer/service-binding-operator/pkg/log"
-	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
+	"github.com/redhat-developer/service-binding-operator/pkg/tracing"
 )
 
 // changeTriggerEnv hijacking environment in order to trigger a change
@@ -34,24 +36,12 @@ type binder struct {
 	dynClient  dynamic.Interface        // kubernetes dynamic api client
 	sbr        *v1alpha1.ServiceBinding // instantiated service binding request
 	volumeKeys []string                 // list of key names used in volume mounts
-	modifier   extraFieldsModifier      // extra modifier for CRDs before updating
+	modifier   ExtraFieldsModifier      // extra modifiers for CRDs before updating
 	restMapper meta.RESTMapper          // RESTMapper to convert GVR from GVK
+	recorder   record.EventRecorder     // events recorder, events are ignored when nil
 	logger     *log.Log                 // logger instance
-}
-
-// extraFieldsModifier is useful for updating backend service which requires additional changes besides
-// env/volumes updating. eg. for knative service we need to remove or update `spec.template.metadata.name`
-// from service template before updating otherwise it will be rejected.
-type extraFieldsModifier interface {
-	ModifyExtraFields(u *unstructured.Unstructured) error
-}
-
-// extraFieldsModifierFunc func receiver type for ExtraFieldsModifier
-type extraFieldsModifierFunc func(u *unstructured.Unstructured) error
-
-// ModifyExtraFields implements ExtraFieldsModifier interface
-func (f extraFieldsModifierFunc) ModifyExtraFields(u *unstructured.Unstructured) error {
-	return f(u)
+	// secretResourceVersion is the intermediary secret's resource version, fetched once when empty
+	secretResourceVersion string
 }
 
 // search objects based in Kind/APIVersion, which contain the labels defined in Application.
@@ -387,6 +377,21 @@ func (b *binder) containerFromUnstructured(container interface{}) (*corev1.Conta
 	return c, nil
 }
 
+// getSecretResourceVersion returns the intermediary secret's resource version, fetching the secret
+// only when the version isn't known yet.
+func (b *binder) getSecretResourceVersion() (string, error) {
+	if b.secretResourceVersion != "" {
+		return b.secretResourceVersion, nil
+	}
+	secretRes := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}
+	existingSecret, err := b.dynClient.Resource(secretRes).Namespace(b.sbr.GetNamespace()).Get(b.sbr.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	b.secretResourceVersion = existingSecret.GetResourceVersion()
+	return b.secretResourceVersion, nil
+}
+
 // updateContainer execute the update of a single container, adding binding items.
 func (b *binder) updateContainer(container interface{}) (map[string]interface{}, error) {
 	c, err := b.containerFromUnstructured(container)
@@ -397,15 +402,14 @@ func (b *binder) updateContainer(container interface{}) (map[string]interface{},
 	// effectively binding the application with intermediary secret
 	c.EnvFrom = b.appendEnvFrom(c.EnvFrom, b.sbr.GetName())
 
-	secretRes := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}
-	existingSecret, err := b.dynClient.Resource(secretRes).Namespace(b.sbr.GetNamespace()).Get(b.sbr.GetName(), metav1.GetOptions{})
+	resourceVersion, err := b.getSecretResourceVersion()
 	if err != nil {
 		return nil, err
 	}
 	// add a special environment variable that is only used to trigger a change in the declaration,
 	// attempting to force a side effect (in case of a Deployment, it would result in its Pods to be
 	// restarted)
-	c.Env = b.appendEnvVar(c.Env, changeTriggerEnv, existingSecret.GetResourceVersion())
+	c.Env = b.appendEnvVar(c.Env, changeTriggerEnv, resourceVersion)
 
 	if len(b.volumeKeys) > 0 {
 		// and adding volume mount entries
@@ -438,7 +442,7 @@ func (b *binder) appendVolumeMounts(volumeMounts []corev1.VolumeMount) []corev1.
 	name := b.sbr.GetName()
 	mountPath := b.sbr.Spec.MountPathPrefix
 	if mountPath == "" {
-		mountPath = "/var/data"
+		mountPath = config.Get().DefaultMountPath
 	}
 
 	for _, v := range volumeMounts {
@@ -566,6 +570,8 @@ func (b *binder) update(objs *unstructured.UnstructuredList) ([]*unstructured.Un
 		if err != nil {
 			return nil, err
 		}
+		b.recordBindingEvents(updated, ApplicationBoundReason, "Bound to", "bound")
+		countWorkloadUpdate(&obj, updated, "bind")
 		updatedObjs = append(updatedObjs, updated)
 	}
 	return updatedObjs, nil
@@ -596,18 +602,36 @@ func (b *binder) remove(objs *unstructured.UnstructuredList) error {
 			return err
 		}
 
-		_, err = b.dynClient.Resource(mapping.Resource).
+		updated, err := b.dynClient.Resource(mapping.Resource).
 			Namespace(updatedObj.GetNamespace()).
 			Update(updatedObj, metav1.UpdateOptions{})
 
 		if err != nil {
 			return err
 		}
+		b.recordBindingEvents(updated, ApplicationUnboundReason, "Unbound from", "unbound")
+		countWorkloadUpdate(&obj, updated, "unbind")
 
 	}
 	return nil
 }
 
+// recordBindingEvents emits events on both the application workload and the Service Binding, so
+// `kubectl describe` shows which binding changed the workload.
+func (b *binder) recordBindingEvents(
+	obj *unstructured.Unstructured,
+	reason string,
+	objAction string,
+	sbrAction string,
+) {
+	sbrName := types.NamespacedName{Namespace: b.sbr.GetNamespace(), Name: b.sbr.GetName()}
+	objName := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	recordEvent(b.recorder, obj, corev1.EventTypeNormal, reason,
+		"%s ServiceBinding %q", objAction, sbrName.String())
+	recordEvent(b.recorder, b.sbr, corev1.EventTypeNormal, reason,
+		"Application %s %q %s", obj.GetKind(), objName.String(), sbrAction)
+}
+
 // unbind select objects subject to binding, and proceed with "remove", which will unbind objects.
 func (b *binder) unbind() error {
 	objs, err := b.search()
@@ -627,6 +651,14 @@ func (b *binder) bind() ([]*unstructured.Unstructured, error) {
 	return b.update(objs)
 }
 
+// withContext returns a copy of the binder which API calls are traced as children of ctx.
+func (b *binder) withContext(ctx context.Context) *binder {
+	c := *b
+	c.ctx = ctx
+	c.dynClient = tracing.NewDynamicClient(ctx, b.dynClient)
+	return &c
+}
+
 // newBinder returns a new Binder instance.
 func newBinder(
 	ctx context.Context,
@@ -637,7 +669,7 @@ func newBinder(
 ) *binder {
 
 	logger := log.NewLog("binder")
-	modifier := buildExtraFieldsModifier(logger, sbr)
+	modifier := buildExtraFieldsModifier(sbr)
 
 	return &binder{
 		ctx:        ctx,
@@ -650,24 +682,16 @@ func newBinder(
 	}
 }
 
-func buildExtraFieldsModifier(logger *log.Log, sbr *v1alpha1.ServiceBinding) extraFieldsModifier {
-	if sbr.Spec.Application != nil {
-		gvr := sbr.Spec.Application.GroupVersionResource
-		ksvcgvr := knativev1.SchemeGroupVersion.WithResource("services")
-		switch gvr.String() {
-		case ksvcgvr.String():
-			pathToRevisionName := "spec.template.metadata.name"
-			return extraFieldsModifierFunc(func(u *unstructured.Unstructured) error {
-				revisionName, ok, err := unstructured.NestedString(u.Object, strings.Split(pathToRevisionName, ".")...)
-				if err == nil && ok {
-					logger.Info("remove revision in knative service template", "name", revisionName)
-					unstructured.RemoveNestedField(u.Object, strings.Split(pathToRevisionName, ".")...)
-				}
-				return nil
-			})
-		default:
-			return nil
-		}
+// buildExtraFieldsModifier returns the chain of modifiers registered for the application GVR, or nil
+// when there are none.
+func buildExtraFieldsModifier(sbr *v1alpha1.ServiceBinding) ExtraFieldsModifier {
+	if sbr.Spec.Application == nil {
+		return nil
 	}
-	return nil
+	gvr := sbr.Spec.Application.GroupVersionResource
+	return extraFieldsModifiers.forGVR(schema.GroupVersionResource{
+		Group:    gvr.Group,
+		Version:  gvr.Version,
+		Resource: gvr.Resource,
+	})
 }
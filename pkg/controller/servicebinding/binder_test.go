@@ -282,7 +282,7 @@ func TestBinderNew(t *testing.T) {
 		)
 		// test binder with extra modifier present
 		ch := make(chan struct{})
-		binder.modifier = extraFieldsModifierFunc(func(u *unstructured.Unstructured) error {
+		binder.modifier = ExtraFieldsModifierFunc(func(u *unstructured.Unstructured) error {
 			close(ch)
 			return nil
 		})
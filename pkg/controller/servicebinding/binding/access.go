@@ -0,0 +1,174 @@
+package binding
+
+import (
+	"fmt"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// AccessChecker asserts whether objects living in a namespace other than the service's can be read.
+type AccessChecker interface {
+	// CanGet returns whether the object with the given resource, namespace and name can be read.
+	CanGet(gvr schema.GroupVersionResource, namespace string, name string) (bool, error)
+}
+
+// subjectAccessReviewGVR is the resource used to assert the permissions of a namespace.
+var subjectAccessReviewGVR = authorizationv1.SchemeGroupVersion.WithResource("subjectaccessreviews")
+
+// subjectAccessChecker asserts access by creating SubjectAccessReview objects on behalf of the service
+// accounts of the ServiceBinding's namespace, so objects in other namespaces are read only when
+// their owners granted that namespace access to them; the operator's own RBAC rules, which allow
+// reading objects in any namespace, aren't taken in consideration.
+type subjectAccessChecker struct {
+	client    dynamic.Interface
+	namespace string
+}
+
+var _ AccessChecker = (*subjectAccessChecker)(nil)
+
+// NewSubjectAccessChecker returns an AccessChecker backed by SubjectAccessReview, asserting access
+// for the service accounts of the given namespace.
+func NewSubjectAccessChecker(client dynamic.Interface, namespace string) AccessChecker {
+	return &subjectAccessChecker{client: client, namespace: namespace}
+}
+
+// serviceAccountsGroup returns the group all service accounts of the given namespace belong to.
+func serviceAccountsGroup(namespace string) string {
+	return "system:serviceaccounts:" + namespace
+}
+
+func (c *subjectAccessChecker) CanGet(
+	gvr schema.GroupVersionResource,
+	namespace string,
+	name string,
+) (bool, error) {
+	review := &authorizationv1.SubjectAccessReview{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: authorizationv1.SchemeGroupVersion.String(),
+			Kind:       "SubjectAccessReview",
+		},
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			Groups: []string{serviceAccountsGroup(c.namespace)},
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "get",
+				Group:     gvr.Group,
+				Version:   gvr.Version,
+				Resource:  gvr.Resource,
+				Name:      name,
+			},
+		},
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(review)
+	if err != nil {
+		return false, err
+	}
+
+	created, err := c.client.Resource(subjectAccessReviewGVR).
+		Create(&unstructured.Unstructured{Object: obj}, v1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	allowed, _, err := unstructured.NestedBool(created.Object, "status", "allowed")
+	if err != nil {
+		return false, err
+	}
+	return allowed, nil
+}
+
+// ErrAccessDenied is returned when an object referenced from a namespace other than the service's
+// can't be read.
+type ErrAccessDenied struct {
+	GVR       schema.GroupVersionResource
+	Namespace string
+	Name      string
+}
+
+func (e *ErrAccessDenied) Error() string {
+	if len(e.Namespace) == 0 {
+		return fmt.Sprintf("access denied to %s %q", e.GVR.Resource, e.Name)
+	}
+	return fmt.Sprintf("access denied to %s %q in namespace %q", e.GVR.Resource, e.Name, e.Namespace)
+}
+
+// locateObject returns the namespace and name of the object referenced by u, which name is found in
+// path. The namespace is read from namespacePath when informed; otherwise, a value in the
+// "namespace/name" form is accepted, and the service's namespace is used when the value contains
+// only the name.
+func locateObject(
+	u *unstructured.Unstructured,
+	path []string,
+	jsonPath string,
+	namespacePath *fieldPath,
+) (string, string, error) {
+	name, ok, err := lookupString(u.Object, path, jsonPath)
+	if err != nil {
+		return "", "", err
+	}
+	if !ok {
+		return "", "", errNotFound
+	}
+
+	ns := u.GetNamespace()
+	if namespacePath != nil {
+		refNs, ok, err := lookupString(u.Object, namespacePath.segments, namespacePath.jsonPathExpr())
+		if err != nil {
+			return "", "", err
+		}
+		if ok && len(refNs) > 0 {
+			ns = refNs
+		}
+	} else if p := strings.SplitN(name, "/", 2); len(p) == 2 {
+		ns, name = p[0], p[1]
+	}
+
+	if len(ns) == 0 || len(name) == 0 {
+		return "", "", fmt.Errorf("invalid object reference %q", ns+"/"+name)
+	}
+
+	return ns, name, nil
+}
+
+// checkAccess asserts the object can be read when it lives in a namespace other than the service's;
+// objects in other namespaces are never read when an AccessChecker isn't available.
+func checkAccess(
+	accessChecker AccessChecker,
+	u *unstructured.Unstructured,
+	gvr schema.GroupVersionResource,
+	ns string,
+	name string,
+) error {
+	return CheckAccess(accessChecker, u.GetNamespace(), gvr, ns, name)
+}
+
+// CheckAccess asserts the object with the given resource, namespace and name can be read from the
+// given namespace; objects in other namespaces are never read when an AccessChecker isn't available.
+func CheckAccess(
+	accessChecker AccessChecker,
+	fromNs string,
+	gvr schema.GroupVersionResource,
+	ns string,
+	name string,
+) error {
+	if ns == fromNs {
+		return nil
+	}
+	if accessChecker == nil {
+		return &ErrAccessDenied{GVR: gvr, Namespace: ns, Name: name}
+	}
+	allowed, err := accessChecker.CanGet(gvr, ns, name)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return &ErrAccessDenied{GVR: gvr, Namespace: ns, Name: name}
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package binding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/testutils"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+// fakeAccessChecker allows reading objects only from the namespaces it contains.
+type fakeAccessChecker map[string]bool
+
+func (c fakeAccessChecker) CanGet(gvr schema.GroupVersionResource, namespace string, name string) (bool, error) {
+	return c[namespace], nil
+}
+
+func TestLocateObject(t *testing.T) {
+	service := func(status map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"namespace": "service-ns"},
+			"status":   status,
+		}}
+	}
+	namespacePath, err := parseFieldPath("{.status.secretRef.namespace}")
+	require.NoError(t, err)
+
+	t.Run("name only", func(t *testing.T) {
+		ns, name, err := locateObject(
+			service(map[string]interface{}{"secret": "creds"}), []string{"status", "secret"}, "", nil)
+		require.NoError(t, err)
+		require.Equal(t, "service-ns", ns)
+		require.Equal(t, "creds", name)
+	})
+
+	t.Run("namespace/name value", func(t *testing.T) {
+		ns, name, err := locateObject(
+			service(map[string]interface{}{"secret": "operator-ns/creds"}), []string{"status", "secret"}, "", nil)
+		require.NoError(t, err)
+		require.Equal(t, "operator-ns", ns)
+		require.Equal(t, "creds", name)
+	})
+
+	t.Run("namespace path", func(t *testing.T) {
+		u := service(map[string]interface{}{
+			"secretRef": map[string]interface{}{"namespace": "operator-ns", "name": "creds"},
+		})
+		ns, name, err := locateObject(u, []string{"status", "secretRef", "name"}, "", namespacePath)
+		require.NoError(t, err)
+		require.Equal(t, "operator-ns", ns)
+		require.Equal(t, "creds", name)
+	})
+
+	t.Run("invalid namespace/name value", func(t *testing.T) {
+		_, _, err := locateObject(
+			service(map[string]interface{}{"secret": "operator-ns/"}), []string{"status", "secret"}, "", nil)
+		require.Error(t, err)
+	})
+}
+
+func TestSubjectAccessChecker(t *testing.T) {
+	secretsGVR := corev1.SchemeGroupVersion.WithResource("secrets")
+
+	// only the operator's service accounts are allowed to read the secret
+	client := mocks.NewFake(t, "test").FakeDynClient()
+	var reviewed map[string]interface{}
+	client.PrependReactor("create", "subjectaccessreviews",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			u := action.(k8stesting.CreateAction).GetObject().(*unstructured.Unstructured)
+			reviewed, _, _ = unstructured.NestedMap(u.Object, "spec", "resourceAttributes")
+			groups, _, _ := unstructured.NestedStringSlice(u.Object, "spec", "groups")
+			allowed := len(groups) == 1 && groups[0] == "system:serviceaccounts:operator-ns"
+			require.NoError(t, unstructured.SetNestedField(u.Object, allowed, "status", "allowed"))
+			return true, u, nil
+		})
+	client.PrependReactor("create", "selfsubjectaccessreviews",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			t.Fatal("the operator's own permissions must not be reviewed")
+			return true, nil, nil
+		})
+
+	got, err := NewSubjectAccessChecker(client, "operator-ns").CanGet(secretsGVR, "operator-ns", "creds")
+	require.NoError(t, err)
+	require.True(t, got)
+
+	got, err = NewSubjectAccessChecker(client, "tenant-ns").CanGet(secretsGVR, "operator-ns", "creds")
+	require.NoError(t, err)
+	require.False(t, got, "the tenant wasn't granted access to the secret")
+	require.Equal(t, map[string]interface{}{
+		"namespace": "operator-ns",
+		"verb":      "get",
+		"version":   "v1",
+		"resource":  "secrets",
+		"name":      "creds",
+	}, reviewed)
+}
+
+func TestSpecHandlerCrossNamespace(t *testing.T) {
+	f := mocks.NewFake(t, "service-ns")
+	f.AddMockResource(&corev1.Secret{
+		TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "operator-ns",
+			Name:      "creds",
+		},
+		Data: map[string][]byte{
+			"password": []byte("hunter2"),
+		},
+	})
+
+	service := unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"namespace": "service-ns"},
+		"status": map[string]interface{}{
+			"secretRef":    map[string]interface{}{"namespace": "operator-ns", "name": "creds"},
+			"secretWithNs": "operator-ns/creds",
+		},
+	}}
+
+	handle := func(value string, accessChecker AccessChecker) (result, error) {
+		h, err := NewSpecHandler(
+			f.FakeDynClient(),
+			"service.binding",
+			value,
+			service,
+			testutils.BuildTestRESTMapper(),
+			accessChecker,
+		)
+		require.NoError(t, err)
+		return h.Handle()
+	}
+
+	allowed := fakeAccessChecker{"operator-ns": true}
+
+	t.Run("should read secret using namespace path", func(t *testing.T) {
+		got, err := handle(
+			"path={.status.secretRef.name},namespacePath={.status.secretRef.namespace},objectType=Secret",
+			allowed,
+		)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"password": "hunter2"}, got.Data)
+	})
+
+	t.Run("should read secret using namespace/name value", func(t *testing.T) {
+		got, err := handle("path={.status.secretWithNs},objectType=Secret,sourceKey=password", allowed)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"": "hunter2"}, got.Data)
+	})
+
+	t.Run("should deny access when not allowed", func(t *testing.T) {
+		_, err := handle("path={.status.secretWithNs},objectType=Secret", fakeAccessChecker{})
+		require.Error(t, err)
+		require.IsType(t, &ErrAccessDenied{}, err)
+	})
+
+	t.Run("should deny access without access checker", func(t *testing.T) {
+		_, err := handle("path={.status.secretWithNs},objectType=Secret", nil)
+		require.IsType(t, &ErrAccessDenied{}, err)
+	})
+}
+
+func TestSpecHandlerClusterScoped(t *testing.T) {
+	f := mocks.NewFake(t, "service-ns")
+	f.AddMockResource(&corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "shared",
+			Labels: map[string]string{"tier": "gold"},
+		},
+	})
+
+	restMapper := testutils.BuildTestRESTMapper().(*meta.DefaultRESTMapper)
+	restMapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+
+	service := unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"namespace": "service-ns"},
+		"status":   map[string]interface{}{"namespaceName": "shared"},
+	}}
+
+	handle := func(accessChecker AccessChecker) (result, error) {
+		h, err := NewSpecHandler(
+			f.FakeDynClient(),
+			"service.binding/tier",
+			"path={.status.namespaceName},objectType=Namespace,sourcePath={.metadata.labels.tier}",
+			service,
+			restMapper,
+			accessChecker,
+		)
+		require.NoError(t, err)
+		return h.Handle()
+	}
+
+	t.Run("should read object when granted cluster-wide access", func(t *testing.T) {
+		got, err := handle(fakeAccessChecker{"": true})
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"tier": "gold"}, got.Data)
+	})
+
+	t.Run("should deny access when not allowed", func(t *testing.T) {
+		_, err := handle(fakeAccessChecker{"service-ns": true})
+		require.IsType(t, &ErrAccessDenied{}, err)
+		require.Equal(t, `access denied to namespaces "shared"`, err.Error())
+	})
+
+	t.Run("should deny access without access checker", func(t *testing.T) {
+		_, err := handle(nil)
+		require.IsType(t, &ErrAccessDenied{}, err)
+	})
+}
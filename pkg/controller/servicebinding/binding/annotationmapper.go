@@ -5,13 +5,19 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/dynamic"
 )
 
 type annotationBackedDefinitionBuilder struct {
-	kubeClient dynamic.Interface
-	name       string
-	value      string
+	kubeClient    dynamic.Interface
+	accessChecker AccessChecker
+	restMapper    meta.RESTMapper
+	name          string
+	value         string
+	// model and resolvedOutputName are available after Build has been called.
+	model              *model
+	resolvedOutputName string
 }
 
 var _ DefinitionBuilder = (*annotationBackedDefinitionBuilder)(nil)
@@ -19,12 +25,17 @@ var _ DefinitionBuilder = (*annotationBackedDefinitionBuilder)(nil)
 type modelKey string
 
 const (
-	pathModelKey        modelKey = "path"
-	objectTypeModelKey  modelKey = "objectType"
-	sourceKeyModelKey   modelKey = "sourceKey"
-	sourceValueModelKey modelKey = "sourceValue"
-	elementTypeModelKey modelKey = "elementType"
-	AnnotationPrefix             = "service.binding"
+	pathModelKey          modelKey = "path"
+	objectTypeModelKey    modelKey = "objectType"
+	sourceKeyModelKey     modelKey = "sourceKey"
+	sourceValueModelKey   modelKey = "sourceValue"
+	elementTypeModelKey   modelKey = "elementType"
+	sourcePathModelKey    modelKey = "sourcePath"
+	namespacePathModelKey modelKey = "namespacePath"
+	optionalModelKey      modelKey = "optional"
+	requiredModelKey      modelKey = "required"
+	defaultModelKey       modelKey = "default"
+	AnnotationPrefix               = "service.binding"
 )
 
 func (m *annotationBackedDefinitionBuilder) outputName() (string, error) {
@@ -40,6 +51,20 @@ func (m *annotationBackedDefinitionBuilder) outputName() (string, error) {
 	return "", nil
 }
 
+// missingValue returns the default value configured in the annotation, or an ErrMissingValue error
+// describing how the missing element should be handled.
+func (m *annotationBackedDefinitionBuilder) missingValue() (Value, error) {
+	if m.model.defaultValue != nil {
+		return &value{v: map[string]interface{}{m.resolvedOutputName: *m.model.defaultValue}}, nil
+	}
+	return nil, &ErrMissingValue{
+		Key:      m.name,
+		Path:     m.model.rawPath,
+		Optional: m.model.optional,
+		Required: m.model.required,
+	}
+}
+
 func (m *annotationBackedDefinitionBuilder) Build() (Definition, error) {
 
 	outputName, err := m.outputName()
@@ -53,44 +78,72 @@ func (m *annotationBackedDefinitionBuilder) Build() (Definition, error) {
 	}
 
 	if len(outputName) == 0 {
-		outputName = mod.path[len(mod.path)-1]
+		if len(mod.name) == 0 {
+			return nil, fmt.Errorf("can't derive a name from path %q, use %s/<name> as annotation key", mod.rawPath, AnnotationPrefix)
+		}
+		outputName = mod.name
 	}
 
+	m.model = mod
+	m.resolvedOutputName = outputName
+
 	switch {
+	case mod.isObjectReference():
+		return &objectFieldDefinition{
+			kubeClient:    m.kubeClient,
+			accessChecker: m.accessChecker,
+			restMapper:    m.restMapper,
+			objectType:    mod.objectType,
+			outputName:    outputName,
+			path:          mod.path,
+			jsonPath:      mod.jsonPath,
+			namespacePath: mod.namespacePath,
+			sourcePath:    mod.sourcePath,
+		}, nil
+
 	case mod.isStringElementType() && mod.isStringObjectType():
 		return &stringDefinition{
 			outputName: outputName,
 			path:       mod.path,
+			jsonPath:   mod.jsonPath,
 		}, nil
 
 	case mod.isStringElementType() && mod.hasDataField():
 		return &stringFromDataFieldDefinition{
-			kubeClient: m.kubeClient,
-			objectType: mod.objectType,
-			outputName: outputName,
-			path:       mod.path,
-			sourceKey:  mod.sourceKey,
+			kubeClient:    m.kubeClient,
+			accessChecker: m.accessChecker,
+			objectType:    mod.objectType,
+			outputName:    outputName,
+			path:          mod.path,
+			jsonPath:      mod.jsonPath,
+			namespacePath: mod.namespacePath,
+			sourceKey:     mod.sourceKey,
 		}, nil
 
 	case mod.isMapElementType() && mod.hasDataField():
 		return &mapFromDataFieldDefinition{
-			kubeClient:  m.kubeClient,
-			objectType:  mod.objectType,
-			outputName:  outputName,
-			path:        mod.path,
-			sourceValue: mod.sourceValue,
+			kubeClient:    m.kubeClient,
+			accessChecker: m.accessChecker,
+			objectType:    mod.objectType,
+			outputName:    outputName,
+			path:          mod.path,
+			jsonPath:      mod.jsonPath,
+			namespacePath: mod.namespacePath,
+			sourceValue:   mod.sourceValue,
 		}, nil
 
 	case mod.isMapElementType() && mod.isStringObjectType():
 		return &stringOfMapDefinition{
 			outputName: outputName,
 			path:       mod.path,
+			jsonPath:   mod.jsonPath,
 		}, nil
 
 	case mod.isSliceOfMapsElementType():
 		return &sliceOfMapsFromPathDefinition{
 			outputName:  outputName,
 			path:        mod.path,
+			jsonPath:    mod.jsonPath,
 			sourceKey:   mod.sourceKey,
 			sourceValue: mod.sourceValue,
 		}, nil
@@ -99,6 +152,7 @@ func (m *annotationBackedDefinitionBuilder) Build() (Definition, error) {
 		return &sliceOfStringsFromPathDefinition{
 			outputName:  outputName,
 			path:        mod.path,
+			jsonPath:    mod.jsonPath,
 			sourceValue: mod.sourceValue,
 		}, nil
 	}
@@ -4,7 +4,9 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -38,6 +40,48 @@ const (
 	stringElementType elementType = "string"
 )
 
+// errNotFound is returned by definitions when the element referenced by the annotation can't be
+// found.
+var errNotFound = errors.New("not found")
+
+// isBuiltin asserts whether the object type is one of string, Secret or ConfigMap.
+func (t objectType) isBuiltin() bool {
+	switch t {
+	case stringObjectType, secretObjectType, configMapObjectType, emptyObjectType:
+		return true
+	}
+	return false
+}
+
+// groupVersionKind parses the object type, which can be informed as "Kind", "version/Kind" or
+// "group/version/Kind"; the core API group is assumed when a group is not informed.
+func (t objectType) groupVersionKind() (schema.GroupVersionKind, error) {
+	parts := strings.Split(string(t), "/")
+	switch len(parts) {
+	case 1:
+		return schema.GroupVersionKind{Kind: parts[0]}, nil
+	case 2:
+		return schema.GroupVersionKind{Version: parts[0], Kind: parts[1]}, nil
+	case 3:
+		return schema.GroupVersionKind{Group: parts[0], Version: parts[1], Kind: parts[2]}, nil
+	}
+	return schema.GroupVersionKind{}, fmt.Errorf("invalid objectType %q", t)
+}
+
+// ObjectReference identifies an object, other than the service itself, read while collecting
+// binding data.
+type ObjectReference struct {
+	schema.GroupVersionKind
+	Namespace string
+	Name      string
+}
+
+// referencingDefinition is implemented by definitions reading objects other than the service.
+type referencingDefinition interface {
+	// references returns the objects the definition attempted to read during Apply.
+	references() []ObjectReference
+}
+
 type Definition interface {
 	GetPath() []string
 	Apply(u *unstructured.Unstructured) (Value, error)
@@ -50,6 +94,7 @@ type DefinitionBuilder interface {
 type stringDefinition struct {
 	outputName string
 	path       []string
+	jsonPath   string
 }
 
 var _ Definition = (*stringDefinition)(nil)
@@ -65,12 +110,12 @@ func (d *stringDefinition) getOutputName() string {
 func (d *stringDefinition) GetPath() []string { return d.path[0 : len(d.path)-1] }
 
 func (d *stringDefinition) Apply(u *unstructured.Unstructured) (Value, error) {
-	val, ok, err := unstructured.NestedFieldCopy(u.Object, d.path...)
+	val, ok, err := lookup(u.Object, d.path, d.jsonPath)
 	if err != nil {
 		return nil, err
 	}
 	if !ok {
-		return nil, errors.New("not found")
+		return nil, errNotFound
 	}
 
 	m := map[string]interface{}{
@@ -81,11 +126,14 @@ func (d *stringDefinition) Apply(u *unstructured.Unstructured) (Value, error) {
 }
 
 type stringFromDataFieldDefinition struct {
-	kubeClient dynamic.Interface
-	objectType objectType
-	outputName string
-	path       []string
-	sourceKey  string
+	kubeClient    dynamic.Interface
+	accessChecker AccessChecker
+	objectType    objectType
+	outputName    string
+	path          []string
+	jsonPath      string
+	namespacePath *fieldPath
+	sourceKey     string
 }
 
 var _ Definition = (*stringFromDataFieldDefinition)(nil)
@@ -104,15 +152,15 @@ func (d *stringFromDataFieldDefinition) Apply(u *unstructured.Unstructured) (Val
 		resource = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"}
 	}
 
-	resourceName, ok, err := unstructured.NestedString(u.Object, d.path...)
+	ns, resourceName, err := locateObject(u, d.path, d.jsonPath, d.namespacePath)
 	if err != nil {
 		return nil, err
 	}
-	if !ok {
-		return nil, errors.New("not found")
+	if err := checkAccess(d.accessChecker, u, resource, ns, resourceName); err != nil {
+		return nil, err
 	}
 
-	otherObj, err := d.kubeClient.Resource(resource).Namespace(u.GetNamespace()).Get(resourceName, v1.GetOptions{})
+	otherObj, err := d.kubeClient.Resource(resource).Namespace(ns).Get(resourceName, v1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -122,7 +170,7 @@ func (d *stringFromDataFieldDefinition) Apply(u *unstructured.Unstructured) (Val
 		return nil, err
 	}
 	if !ok {
-		return nil, errors.New("not found")
+		return nil, errNotFound
 	}
 	if d.objectType == secretObjectType {
 		n, err := base64.StdEncoding.DecodeString(val)
@@ -138,11 +186,14 @@ func (d *stringFromDataFieldDefinition) Apply(u *unstructured.Unstructured) (Val
 }
 
 type mapFromDataFieldDefinition struct {
-	kubeClient  dynamic.Interface
-	objectType  objectType
-	outputName  string
-	sourceValue string
-	path        []string
+	kubeClient    dynamic.Interface
+	accessChecker AccessChecker
+	objectType    objectType
+	outputName    string
+	sourceValue   string
+	path          []string
+	jsonPath      string
+	namespacePath *fieldPath
 }
 
 var _ Definition = (*mapFromDataFieldDefinition)(nil)
@@ -161,16 +212,15 @@ func (d *mapFromDataFieldDefinition) Apply(u *unstructured.Unstructured) (Value,
 		resource = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"}
 	}
 
-	resourceName, ok, err := unstructured.NestedString(u.Object, d.path...)
+	ns, resourceName, err := locateObject(u, d.path, d.jsonPath, d.namespacePath)
 	if err != nil {
 		return nil, err
 	}
-	if !ok {
-		return nil, errors.New("not found")
+	if err := checkAccess(d.accessChecker, u, resource, ns, resourceName); err != nil {
+		return nil, err
 	}
 
-	otherObj, err := d.kubeClient.Resource(resource).Namespace(u.GetNamespace()).
-		Get(resourceName, v1.GetOptions{})
+	otherObj, err := d.kubeClient.Resource(resource).Namespace(ns).Get(resourceName, v1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -180,7 +230,7 @@ func (d *mapFromDataFieldDefinition) Apply(u *unstructured.Unstructured) (Value,
 		return nil, err
 	}
 	if !ok {
-		return nil, errors.New("not found")
+		return nil, errNotFound
 	}
 
 	outputVal := make(map[string]string)
@@ -208,6 +258,7 @@ func (d *mapFromDataFieldDefinition) Apply(u *unstructured.Unstructured) (Value,
 type stringOfMapDefinition struct {
 	outputName string
 	path       []string
+	jsonPath   string
 }
 
 var _ Definition = (*stringOfMapDefinition)(nil)
@@ -215,12 +266,12 @@ var _ Definition = (*stringOfMapDefinition)(nil)
 func (d *stringOfMapDefinition) GetPath() []string { return d.path }
 
 func (d *stringOfMapDefinition) Apply(u *unstructured.Unstructured) (Value, error) {
-	val, ok, err := unstructured.NestedFieldNoCopy(u.Object, d.path...)
+	val, ok, err := lookup(u.Object, d.path, d.jsonPath)
 	if err != nil {
 		return nil, err
 	}
 	if !ok {
-		return nil, errors.New("not found")
+		return nil, errNotFound
 	}
 
 	outputName := d.outputName
@@ -237,6 +288,7 @@ func (d *stringOfMapDefinition) Apply(u *unstructured.Unstructured) (Value, erro
 type sliceOfMapsFromPathDefinition struct {
 	outputName  string
 	path        []string
+	jsonPath    string
 	sourceKey   string
 	sourceValue string
 }
@@ -246,12 +298,12 @@ var _ Definition = (*sliceOfMapsFromPathDefinition)(nil)
 func (d *sliceOfMapsFromPathDefinition) GetPath() []string { return d.path[0 : len(d.path)-1] }
 
 func (d *sliceOfMapsFromPathDefinition) Apply(u *unstructured.Unstructured) (Value, error) {
-	val, ok, err := unstructured.NestedSlice(u.Object, d.path...)
+	val, ok, err := lookupSlice(u.Object, d.path, d.jsonPath)
 	if err != nil {
 		return nil, err
 	}
 	if !ok {
-		return nil, errors.New("not found")
+		return nil, errNotFound
 	}
 
 	v := make(map[string]interface{})
@@ -270,6 +322,7 @@ func (d *sliceOfMapsFromPathDefinition) Apply(u *unstructured.Unstructured) (Val
 type sliceOfStringsFromPathDefinition struct {
 	outputName  string
 	path        []string
+	jsonPath    string
 	sourceValue string
 }
 
@@ -278,12 +331,12 @@ var _ Definition = (*sliceOfStringsFromPathDefinition)(nil)
 func (d *sliceOfStringsFromPathDefinition) GetPath() []string { return d.path[0 : len(d.path)-1] }
 
 func (d *sliceOfStringsFromPathDefinition) Apply(u *unstructured.Unstructured) (Value, error) {
-	val, ok, err := unstructured.NestedSlice(u.Object, d.path...)
+	val, ok, err := lookupSlice(u.Object, d.path, d.jsonPath)
 	if err != nil {
 		return nil, err
 	}
 	if !ok {
-		return nil, errors.New("not found")
+		return nil, errNotFound
 	}
 
 	v := make([]interface{}, 0, len(val))
@@ -303,3 +356,124 @@ func (d *sliceOfStringsFromPathDefinition) Apply(u *unstructured.Unstructured) (
 
 	return &value{v: map[string]interface{}{d.outputName: v}}, nil
 }
+
+// objectFieldDefinition extracts the element found in sourcePath from an arbitrary object, which
+// name is found in path and its kind is informed by objectType.
+type objectFieldDefinition struct {
+	kubeClient    dynamic.Interface
+	accessChecker AccessChecker
+	restMapper    meta.RESTMapper
+	objectType    objectType
+	outputName    string
+	path          []string
+	jsonPath      string
+	namespacePath *fieldPath
+	sourcePath    *fieldPath
+	refs          []ObjectReference
+}
+
+var _ Definition = (*objectFieldDefinition)(nil)
+var _ referencingDefinition = (*objectFieldDefinition)(nil)
+
+func (d *objectFieldDefinition) GetPath() []string { return d.path }
+
+func (d *objectFieldDefinition) references() []ObjectReference { return d.refs }
+
+// restMapping returns the RESTMapping for the given gvk, using the preferred version when the
+// version isn't informed.
+func restMapping(restMapper meta.RESTMapper, gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	if len(gvk.Version) == 0 {
+		return restMapper.RESTMapping(gvk.GroupKind())
+	}
+	return restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+}
+
+// secretGroupKind is the GroupKind of core Secrets.
+var secretGroupKind = schema.GroupKind{Kind: string(secretObjectType)}
+
+// decodeValue decodes base64 encoded strings when decode is true, returning all other values as is.
+func decodeValue(val interface{}, decode bool) (interface{}, error) {
+	s, ok := val.(string)
+	if !decode || !ok {
+		return val, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (d *objectFieldDefinition) Apply(u *unstructured.Unstructured) (Value, error) {
+	if d.kubeClient == nil {
+		return nil, errors.New("kubeClient required for this functionality")
+	}
+	if d.restMapper == nil {
+		return nil, errors.New("restMapper required for this functionality")
+	}
+
+	gvk, err := d.objectType.groupVersionKind()
+	if err != nil {
+		return nil, err
+	}
+
+	ns, resourceName, err := locateObject(u, d.path, d.jsonPath, d.namespacePath)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping, err := restMapping(d.restMapper, gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	var resourceClient dynamic.ResourceInterface = d.kubeClient.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		resourceClient = d.kubeClient.Resource(mapping.Resource).Namespace(ns)
+	} else {
+		ns = ""
+	}
+	// cluster scoped objects are reviewed with an empty namespace, which requires a cluster-wide grant
+	if err := checkAccess(d.accessChecker, u, mapping.Resource, ns, resourceName); err != nil {
+		return nil, err
+	}
+
+	// the reference is recorded before reading the object, so its creation can also be noticed
+	d.refs = append(d.refs, ObjectReference{
+		GroupVersionKind: mapping.GroupVersionKind,
+		Namespace:        ns,
+		Name:             resourceName,
+	})
+
+	otherObj, err := resourceClient.Get(resourceName, v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	val, ok, err := lookup(otherObj.Object, d.sourcePath.segments, d.sourcePath.jsonPathExpr())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errNotFound
+	}
+
+	// values stored in a Secret's data are base64 encoded
+	decode := mapping.GroupVersionKind.GroupKind() == secretGroupKind && d.sourcePath.segments[0] == "data"
+
+	if m, ok := val.(map[string]interface{}); ok {
+		outputVal := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			if outputVal[k], err = decodeValue(v, decode); err != nil {
+				return nil, err
+			}
+		}
+		return &value{v: outputVal}, nil
+	}
+
+	outputVal, err := decodeValue(val, decode)
+	if err != nil {
+		return nil, err
+	}
+	return &value{v: map[string]interface{}{d.outputName: outputVal}}, nil
+}
@@ -0,0 +1,239 @@
+package binding
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// fieldPath is the parsed representation of the path informed in a binding annotation, evaluated
+// with k8s.io/client-go/util/jsonpath semantics.
+type fieldPath struct {
+	// expr is the normalized JSONPath expression, including curly braces; bracket notation for
+	// keys (e.g. "['tls.crt']") is converted to escaped fields (e.g. ".tls\.crt").
+	expr string
+	// segments contains the field names present in the expression in order of appearance; array
+	// indexes, filters and recursive descent are not represented. It is used to deterministically
+	// name the collected values.
+	segments []string
+	// simple indicates the expression is composed only by field names, and can be resolved by
+	// walking segments.
+	simple bool
+	// name is the name given to the collected value when the annotation doesn't inform one; it is
+	// empty when the value is selected by a filter.
+	name string
+}
+
+// normalizeBracketKeys converts bracket notation keys, such as "['tls.crt']" or `["tls.crt"]`, into
+// escaped fields, such as ".tls\.crt", since the parser would otherwise split dotted keys and reject
+// keys containing spaces or other special characters. Brackets containing anything other than a
+// quoted key (array indexes, slices and filters), and keys containing a backslash, which can't be
+// escaped, are kept as is.
+func normalizeBracketKeys(expr string) string {
+	var b strings.Builder
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		if c == '[' && i+1 < len(expr) && (expr[i+1] == '\'' || expr[i+1] == '"') {
+			quote := expr[i+1]
+			end := strings.IndexByte(expr[i+2:], quote)
+			if end >= 0 && i+2+end+1 < len(expr) && expr[i+2+end+1] == ']' {
+				key := expr[i+2 : i+2+end]
+				if key != "" && !strings.Contains(key, `\`) {
+					b.WriteByte('.')
+					b.WriteString(escapeFieldKey(key))
+					i = i + 2 + end + 1
+					continue
+				}
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// escapeFieldKey escapes the characters the parser would take as the end of a field name.
+func escapeFieldKey(key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		switch r {
+		case '.', ',', '[', ']', '$', '@', '{', '}', ' ', '\t', '\r', '\n':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// collectSegments walks the given nodes collecting field names, and returns whether only field nodes
+// have been found.
+func collectSegments(nodes []jsonpath.Node) ([]string, bool) {
+	segments := make([]string, 0, len(nodes))
+	simple := true
+	for _, n := range nodes {
+		switch t := n.(type) {
+		case *jsonpath.FieldNode:
+			segments = append(segments, t.Value)
+		case *jsonpath.ListNode:
+			s, ok := collectSegments(t.Nodes)
+			segments = append(segments, s...)
+			simple = simple && ok
+		default:
+			simple = false
+		}
+	}
+	return segments, simple
+}
+
+// flattenNodes returns the nodes contained in the given nodes and their lists, in order of
+// appearance.
+func flattenNodes(nodes []jsonpath.Node) []jsonpath.Node {
+	flattened := make([]jsonpath.Node, 0, len(nodes))
+	for _, n := range nodes {
+		if l, ok := n.(*jsonpath.ListNode); ok {
+			flattened = append(flattened, flattenNodes(l.Nodes)...)
+			continue
+		}
+		flattened = append(flattened, n)
+	}
+	return flattened
+}
+
+// derivedName names the value found in the given nodes after the last field name. Array indexes are
+// part of the name, joined with the field names from the one preceding the first index, so
+// "{.status.hosts[0]}" and "{.status.hosts[1]}" are named "hosts_0" and "hosts_1", and
+// "{.status.endpoints[0].host}" is named "endpoints_0_host". Values selected by a filter can't be
+// told apart by their path, and an empty name is returned.
+func derivedName(nodes []jsonpath.Node) string {
+	var parts []string
+	start := -1
+	for _, n := range flattenNodes(nodes) {
+		switch t := n.(type) {
+		case *jsonpath.FieldNode:
+			parts = append(parts, t.Value)
+		case *jsonpath.ArrayNode:
+			// a single index is parsed as a slice which end is derived from its start
+			if !t.Params[0].Known || !t.Params[1].Derived {
+				continue
+			}
+			if start < 0 {
+				start = len(parts) - 1
+				if start < 0 {
+					start = 0
+				}
+			}
+			parts = append(parts, strconv.Itoa(t.Params[0].Value))
+		case *jsonpath.FilterNode:
+			return ""
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	if start < 0 {
+		return parts[len(parts)-1]
+	}
+	return strings.Join(parts[start:], "_")
+}
+
+// parseFieldPath parses the given JSONPath expression, which should be enclosed in curly braces.
+func parseFieldPath(raw string) (*fieldPath, error) {
+	if !strings.HasPrefix(raw, "{") || !strings.HasSuffix(raw, "}") {
+		return nil, fmt.Errorf("path has invalid syntax: %q", raw)
+	}
+
+	expr := normalizeBracketKeys(raw)
+	p, err := jsonpath.Parse("path", expr)
+	if err != nil {
+		return nil, fmt.Errorf("path has invalid syntax: %q: %s", raw, err)
+	}
+
+	if len(p.Root.Nodes) != 1 {
+		return nil, fmt.Errorf("path has invalid syntax: %q", raw)
+	}
+	segments, simple := collectSegments(p.Root.Nodes)
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("path does not contain any field: %q", raw)
+	}
+
+	return &fieldPath{
+		expr:     expr,
+		segments: segments,
+		simple:   simple,
+		name:     derivedName(p.Root.Nodes),
+	}, nil
+}
+
+// jsonPathExpr returns the JSONPath expression to evaluate, or an empty string in the case the path
+// can be resolved by walking its segments.
+func (p *fieldPath) jsonPathExpr() string {
+	if p.simple {
+		return ""
+	}
+	return p.expr
+}
+
+// lookup returns the value found in obj. When expr is informed it is evaluated as a JSONPath
+// expression, otherwise path is used as a sequence of field names. In the case the expression
+// yields more than one result, a slice containing all results is returned.
+func lookup(obj map[string]interface{}, path []string, expr string) (interface{}, bool, error) {
+	if len(expr) == 0 {
+		return unstructured.NestedFieldCopy(obj, path...)
+	}
+
+	j := jsonpath.New("path").AllowMissingKeys(true)
+	if err := j.Parse(expr); err != nil {
+		return nil, false, err
+	}
+	results, err := j.FindResults(obj)
+	if err != nil {
+		return nil, false, err
+	}
+
+	values := make([]interface{}, 0)
+	for _, r := range results {
+		for _, v := range r {
+			if v.IsValid() && v.CanInterface() {
+				values = append(values, runtime.DeepCopyJSONValue(v.Interface()))
+			}
+		}
+	}
+
+	switch len(values) {
+	case 0:
+		return nil, false, nil
+	case 1:
+		return values[0], true, nil
+	default:
+		return values, true, nil
+	}
+}
+
+// lookupString returns the string value found in obj; see lookup.
+func lookupString(obj map[string]interface{}, path []string, expr string) (string, bool, error) {
+	val, ok, err := lookup(obj, path, expr)
+	if err != nil || !ok {
+		return "", ok, err
+	}
+	s, ok := val.(string)
+	if !ok {
+		return "", false, fmt.Errorf("%v accessor error: %v is of the type %T, expected string", path, val, val)
+	}
+	return s, true, nil
+}
+
+// lookupSlice returns the slice value found in obj; see lookup.
+func lookupSlice(obj map[string]interface{}, path []string, expr string) ([]interface{}, bool, error) {
+	val, ok, err := lookup(obj, path, expr)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	s, ok := val.([]interface{})
+	if !ok {
+		return nil, false, fmt.Errorf("%v accessor error: %v is of the type %T, expected []interface{}", path, val, val)
+	}
+	return s, true, nil
+}
@@ -0,0 +1,166 @@
+package binding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFieldPath(t *testing.T) {
+	type args struct {
+		description      string
+		raw              string
+		expectedSegments []string
+		expectedSimple   bool
+		expectedName     string
+	}
+
+	testCases := []args{
+		{
+			description:      "dot notation",
+			raw:              "{.status.dbCredentials.password}",
+			expectedSegments: []string{"status", "dbCredentials", "password"},
+			expectedSimple:   true,
+			expectedName:     "password",
+		},
+		{
+			description:      "bracket notation with dotted key",
+			raw:              "{.data['tls.crt']}",
+			expectedSegments: []string{"data", "tls.crt"},
+			expectedSimple:   true,
+			expectedName:     "tls.crt",
+		},
+		{
+			description:      "bracket notation with double quotes",
+			raw:              `{.data["ca.pem"]}`,
+			expectedSegments: []string{"data", "ca.pem"},
+			expectedSimple:   true,
+			expectedName:     "ca.pem",
+		},
+		{
+			description:      "bracket notation with spaces and special characters",
+			raw:              "{.data['tls key, [primary]']}",
+			expectedSegments: []string{"data", "tls key, [primary]"},
+			expectedSimple:   true,
+			expectedName:     "tls key, [primary]",
+		},
+		{
+			description:      "array index",
+			raw:              "{.status.hosts[0]}",
+			expectedSegments: []string{"status", "hosts"},
+			expectedSimple:   false,
+			expectedName:     "hosts_0",
+		},
+		{
+			description:      "array index followed by a field",
+			raw:              "{.status.endpoints[1].host}",
+			expectedSegments: []string{"status", "endpoints", "host"},
+			expectedSimple:   false,
+			expectedName:     "endpoints_1_host",
+		},
+		{
+			description:      "wildcard",
+			raw:              "{.status.hosts[*]}",
+			expectedSegments: []string{"status", "hosts"},
+			expectedSimple:   false,
+			expectedName:     "hosts",
+		},
+		{
+			description:      "filter",
+			raw:              `{.status.endpoints[?(@.type=="primary")].host}`,
+			expectedSegments: []string{"status", "endpoints", "host"},
+			expectedSimple:   false,
+		},
+		{
+			description:      "recursive descent",
+			raw:              "{..password}",
+			expectedSegments: []string{"password"},
+			expectedSimple:   false,
+			expectedName:     "password",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			p, err := parseFieldPath(tc.raw)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSegments, p.segments)
+			require.Equal(t, tc.expectedSimple, p.simple)
+			require.Equal(t, tc.expectedName, p.name)
+		})
+	}
+
+	for _, raw := range []string{".status.secret", "{.status.secret", "{}", "{.status[}"} {
+		t.Run("invalid path "+raw, func(t *testing.T) {
+			_, err := parseFieldPath(raw)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	obj := map[string]interface{}{
+		"data": map[string]interface{}{
+			"tls.crt":       "certificate",
+			"ca bundle.pem": "bundle",
+		},
+		"status": map[string]interface{}{
+			"password": "hunter2",
+			"endpoints": []interface{}{
+				map[string]interface{}{"type": "replica", "host": "replica.example.com"},
+				map[string]interface{}{"type": "primary", "host": "primary.example.com"},
+			},
+		},
+	}
+
+	assertLookup := func(raw string, expectedValue interface{}, expectedFound bool) func(*testing.T) {
+		return func(t *testing.T) {
+			p, err := parseFieldPath(raw)
+			require.NoError(t, err)
+			val, found, err := lookup(obj, p.segments, p.jsonPathExpr())
+			require.NoError(t, err)
+			require.Equal(t, expectedFound, found)
+			require.Equal(t, expectedValue, val)
+		}
+	}
+
+	t.Run("dotted key", assertLookup("{.data['tls.crt']}", "certificate", true))
+	t.Run("key with spaces", assertLookup("{.data['ca bundle.pem']}", "bundle", true))
+	t.Run("filter", assertLookup(`{.status.endpoints[?(@.type=="primary")].host}`, "primary.example.com", true))
+	t.Run("wildcard", assertLookup("{.status.endpoints[*].type}", []interface{}{"replica", "primary"}, true))
+	t.Run("recursive descent", assertLookup("{..password}", "hunter2", true))
+	t.Run("missing field", assertLookup("{.status.endpoints[?(@.type==\"backup\")].host}", nil, false))
+
+	t.Run("returns copies", func(t *testing.T) {
+		for _, expr := range []string{"", "{.status.endpoints[0]}"} {
+			path := []string{"data"}
+			if expr != "" {
+				path = []string{"status", "endpoints"}
+			}
+			val, found, err := lookup(obj, path, expr)
+			require.NoError(t, err)
+			require.True(t, found)
+			val.(map[string]interface{})["type"] = "modified"
+		}
+		require.NotContains(t, obj["data"], "type")
+		endpoints := obj["status"].(map[string]interface{})["endpoints"].([]interface{})
+		require.Equal(t, "replica", endpoints[0].(map[string]interface{})["type"])
+	})
+}
+
+func TestNewModelWithJSONPath(t *testing.T) {
+	m, err := newModel(`path={.status.endpoints[?(@.type=="primary")].host},elementType=string`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"status", "endpoints", "host"}, m.path)
+	require.Equal(t, `{.status.endpoints[?(@.type=="primary")].host}`, m.jsonPath)
+	require.Equal(t, stringElementType, m.elementType)
+
+	m, err = newModel("path={.status.dbCredentials},objectType=Secret")
+	require.NoError(t, err)
+	require.Equal(t, []string{"status", "dbCredentials"}, m.path)
+	require.Empty(t, m.jsonPath)
+	require.Equal(t, mapElementType, m.elementType)
+
+	_, err = newModel("path={.status.dbCredentials},objectType")
+	require.Error(t, err)
+}
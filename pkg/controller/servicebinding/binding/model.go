@@ -3,17 +3,33 @@ package binding
 import (
 	"errors"
 	"fmt"
-	"regexp"
+	"strconv"
 	"strings"
 )
 
 type model struct {
-	path        []string
+	// rawPath is the path as informed in the annotation.
+	rawPath string
+	path    []string
+	// name is the name derived from the path, given to the element when the annotation doesn't
+	// inform one.
+	name        string
+	jsonPath    string
 	elementType elementType
 	objectType  objectType
 	sourceKey   string
 	sourceValue string
 	bindAs      BindingType
+	// sourcePath contains the path to the element in the referenced object, when informed.
+	sourcePath *fieldPath
+	// namespacePath contains the path to the referenced object's namespace, when informed.
+	namespacePath *fieldPath
+	// optional indicates the annotation can be quietly skipped when the element isn't found.
+	optional bool
+	// required indicates the binding can't be collected when the element isn't found.
+	required bool
+	// defaultValue is used when the element isn't found, when informed.
+	defaultValue *string
 }
 
 func (m *model) isStringElementType() bool {
@@ -40,41 +56,93 @@ func (m *model) hasDataField() bool {
 	return m.objectType == secretObjectType || m.objectType == configMapObjectType
 }
 
-func newModel(annotationValue string) (*model, error) {
-	// re contains a regular expression to split the input string using '=' and ',' as separators
-	re := regexp.MustCompile("[=,]")
+// isObjectReference asserts whether the value found in path is the name of an object the element
+// should be extracted from using sourcePath.
+func (m *model) isObjectReference() bool {
+	return m.sourcePath != nil && !m.isStringObjectType()
+}
+
+// parseBoolToken returns the boolean value of the given key, or false when it isn't informed.
+func parseBoolToken(raw map[modelKey]string, key modelKey) (bool, error) {
+	rawValue, found := raw[key]
+	if !found {
+		return false, nil
+	}
+	v, err := strconv.ParseBool(rawValue)
+	if err != nil {
+		return false, fmt.Errorf("invalid value for %s: %q", key, rawValue)
+	}
+	return v, nil
+}
+
+// unquote removes the quotes surrounding the given value, if any.
+func unquote(v string) string {
+	if len(v) >= 2 && (v[0] == '\'' || v[0] == '"') && v[len(v)-1] == v[0] {
+		return v[1 : len(v)-1]
+	}
+	return v
+}
 
-	// split holds the tokens extracted from the input string
-	split := re.Split(annotationValue, -1)
+// splitTokens splits the annotation value in "key=value" tokens separated by commas; commas and
+// equal signs found inside curly braces, brackets, parenthesis or quotes (for example, in JSONPath
+// filters) are not considered separators.
+func splitTokens(annotationValue string) ([]string, error) {
+	tokens := make([]string, 0)
+	depth := 0
+	var quote rune
+	start := 0
+	for i, c := range annotationValue {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '{' || c == '[' || c == '(':
+			depth++
+		case c == '}' || c == ']' || c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			tokens = append(tokens, annotationValue[start:i])
+			start = i + 1
+		}
+	}
+	if quote != 0 || depth != 0 {
+		return nil, fmt.Errorf("invalid input, unbalanced quotes or delimiters: %q", annotationValue)
+	}
+	return append(tokens, annotationValue[start:]), nil
+}
 
-	// its length should be even, since from this point on is assumed a sequence of key and value
-	// pairs as model source
-	if len(split)%2 != 0 {
-		m := fmt.Sprintf("invalid input, odd number of tokens: %q", split)
-		return nil, errors.New(m)
+func newModel(annotationValue string) (*model, error) {
+	// split holds the "key=value" tokens extracted from the input string
+	split, err := splitTokens(annotationValue)
+	if err != nil {
+		return nil, err
 	}
 
-	// extract the tokens into a map, iterating a pair at a time and using the Nth element as key and
-	// Nth+1 as value
+	// extract the tokens into a map, using the content before the first '=' as key and the
+	// remaining as value
 	raw := make(map[modelKey]string)
-	for i := 0; i < len(split); i += 2 {
-		k := modelKey(split[i])
+	for _, token := range split {
+		kv := strings.SplitN(token, "=", 2)
+		if len(kv) != 2 {
+			m := fmt.Sprintf("invalid input, token without value: %q", token)
+			return nil, errors.New(m)
+		}
 		// invalid object type can be created here e.g. "foobar"; this does not pose a problem since
 		// the value will be used in a switch statement further on
-		v := split[i+1]
-		raw[k] = v
+		raw[modelKey(kv[0])] = kv[1]
 	}
 
 	// assert PathModelKey is present
-	path, found := raw[pathModelKey]
+	rawPath, found := raw[pathModelKey]
 	if !found {
 		return nil, fmt.Errorf("path not found: %q", annotationValue)
 	}
-	if !strings.HasPrefix(path, "{") || !strings.HasSuffix(path, "}") {
-		return nil, fmt.Errorf("path has invalid syntax: %q", path)
-	} else {
-		// trim curly braces and initial dot
-		path = strings.Trim(path, "{}.")
+	path, err := parseFieldPath(rawPath)
+	if err != nil {
+		return nil, err
 	}
 
 	// ensure ObjectTypeModelKey has a default value
@@ -89,6 +157,44 @@ func newModel(annotationValue string) (*model, error) {
 		}
 	}
 
+	// sourcePath is mandatory for object types other than string, Secret and ConfigMap
+	var sourcePath *fieldPath
+	if rawSourcePath, found := raw[sourcePathModelKey]; found {
+		if sourcePath, err = parseFieldPath(rawSourcePath); err != nil {
+			return nil, err
+		}
+	} else if !objType.isBuiltin() {
+		return nil, fmt.Errorf("sourcePath is required for objectType %q", objType)
+	}
+
+	// namespacePath is optional, the service's namespace is used when it isn't informed
+	var namespacePath *fieldPath
+	if rawNamespacePath, found := raw[namespacePathModelKey]; found {
+		if namespacePath, err = parseFieldPath(rawNamespacePath); err != nil {
+			return nil, err
+		}
+	}
+
+	optional, err := parseBoolToken(raw, optionalModelKey)
+	if err != nil {
+		return nil, err
+	}
+	required, err := parseBoolToken(raw, requiredModelKey)
+	if err != nil {
+		return nil, err
+	}
+	var defaultValue *string
+	if rawDefault, found := raw[defaultModelKey]; found {
+		v := unquote(rawDefault)
+		defaultValue = &v
+	}
+	if required && optional {
+		return nil, errors.New("required and optional are mutually exclusive")
+	}
+	if required && defaultValue != nil {
+		return nil, errors.New("required and default are mutually exclusive")
+	}
+
 	// ensure sourceKey has a default value
 	sourceKey, found := raw[sourceKeyModelKey]
 	if !found {
@@ -114,6 +220,11 @@ func newModel(annotationValue string) (*model, error) {
 		eltType = stringElementType
 	}
 
+	// the default value is a string, and can't stand for maps or slices
+	if defaultValue != nil && eltType != stringElementType {
+		return nil, fmt.Errorf("default is not supported for elementType %q", eltType)
+	}
+
 	// ensure SourceValueModelKey has a default value
 	sourceValue, found := raw[sourceValueModelKey]
 	if !found {
@@ -126,14 +237,20 @@ func newModel(annotationValue string) (*model, error) {
 		return nil, errors.New("sliceOfMaps elementType requires sourceKey and sourceValue to be present")
 	}
 
-	pathParts := strings.Split(path, ".")
-
 	return &model{
-		path:        pathParts,
-		elementType: eltType,
-		objectType:  objType,
-		sourceValue: sourceValue,
-		sourceKey:   sourceKey,
-		bindAs:      TypeEnvVar,
+		rawPath:       rawPath,
+		path:          path.segments,
+		name:          path.name,
+		jsonPath:      path.jsonPathExpr(),
+		elementType:   eltType,
+		objectType:    objType,
+		sourceValue:   sourceValue,
+		sourceKey:     sourceKey,
+		bindAs:        TypeEnvVar,
+		sourcePath:    sourcePath,
+		namespacePath: namespacePath,
+		optional:      optional,
+		required:      required,
+		defaultValue:  defaultValue,
 	}, nil
 }
@@ -1,11 +1,13 @@
 package binding
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/mitchellh/copystructure"
 	"github.com/redhat-developer/service-binding-operator/pkg/controller/servicebinding/nested"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/dynamic"
@@ -49,19 +51,46 @@ func IsErrHandlerNotFound(err error) bool {
 	return ok
 }
 
+// ErrMissingValue is returned when the element referenced by an annotation can't be found and a
+// default value hasn't been informed.
+type ErrMissingValue struct {
+	// Key is the annotation key.
+	Key string
+	// Path is the path informed in the annotation.
+	Path string
+	// Optional indicates the annotation has been marked as optional.
+	Optional bool
+	// Required indicates the annotation has been marked as required.
+	Required bool
+}
+
+func (e *ErrMissingValue) Error() string {
+	return fmt.Sprintf("value not found for annotation %q using path %q", e.Key, e.Path)
+}
+
 type SpecHandler struct {
 	kubeClient      dynamic.Interface
+	accessChecker   AccessChecker
 	obj             unstructured.Unstructured
 	annotationKey   string
 	annotationValue string
 	restMapper      meta.RESTMapper
+	references      []ObjectReference
+}
+
+// References returns the objects, other than the service, the handler attempted to read; those are
+// available even when Handle returns an error.
+func (s *SpecHandler) References() []ObjectReference {
+	return s.references
 }
 
 func (s *SpecHandler) Handle() (result, error) {
 	builder := &annotationBackedDefinitionBuilder{
-		kubeClient: s.kubeClient,
-		name:       s.annotationKey,
-		value:      s.annotationValue,
+		kubeClient:    s.kubeClient,
+		accessChecker: s.accessChecker,
+		restMapper:    s.restMapper,
+		name:          s.annotationKey,
+		value:         s.annotationValue,
 	}
 	d, err := builder.Build()
 	if err != nil {
@@ -69,6 +98,13 @@ func (s *SpecHandler) Handle() (result, error) {
 	}
 
 	val, err := d.Apply(&s.obj)
+	if r, ok := d.(referencingDefinition); ok {
+		s.references = append(s.references, r.references()...)
+	}
+	// the referenced Secret, ConfigMap or object missing is handled as the value missing
+	if errors.Is(err, errNotFound) || k8serrors.IsNotFound(err) {
+		val, err = builder.missingValue()
+	}
 	if err != nil {
 		return result{}, err
 	}
@@ -109,9 +145,11 @@ func NewSpecHandler(
 	annotationValue string,
 	obj unstructured.Unstructured,
 	restMapper meta.RESTMapper,
+	accessChecker AccessChecker,
 ) (*SpecHandler, error) {
 	return &SpecHandler{
 		kubeClient:      kubeClient,
+		accessChecker:   accessChecker,
 		obj:             obj,
 		annotationKey:   annotationKey,
 		annotationValue: annotationValue,
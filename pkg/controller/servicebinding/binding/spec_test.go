@@ -38,6 +38,7 @@ func TestSpecHandler(t *testing.T) {
 				args.value,
 				unstructured.Unstructured{Object: args.service},
 				restMapper,
+				nil,
 			)
 			require.NoError(t, err)
 			got, err := handler.Handle()
@@ -385,4 +386,309 @@ func TestSpecHandler(t *testing.T) {
 			},
 		},
 	}))
+
+	t.Run("should return value from key containing dots", assertHandler(args{
+		name:  "service.binding",
+		value: "path={.status.certificates['tls.crt']}",
+		service: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"namespace": "the-namespace",
+			},
+			"status": map[string]interface{}{
+				"certificates": map[string]interface{}{
+					"tls.crt": "-----BEGIN CERTIFICATE-----",
+				},
+			},
+		},
+		expectedData: map[string]interface{}{
+			"tls.crt": "-----BEGIN CERTIFICATE-----",
+		},
+		expectedRawData: map[string]interface{}{
+			"status": map[string]interface{}{
+				"certificates": map[string]interface{}{
+					"tls.crt": "-----BEGIN CERTIFICATE-----",
+				},
+			},
+		},
+	}))
+
+	t.Run("should return value selected by JSONPath filter", assertHandler(args{
+		name:  "service.binding/primaryHost",
+		value: `path={.status.endpoints[?(@.type=="primary")].host},elementType=string`,
+		service: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"namespace": "the-namespace",
+			},
+			"status": map[string]interface{}{
+				"endpoints": []interface{}{
+					map[string]interface{}{"type": "replica", "host": "replica.example.com"},
+					map[string]interface{}{"type": "primary", "host": "primary.example.com"},
+				},
+			},
+		},
+		expectedData: map[string]interface{}{
+			"primaryHost": "primary.example.com",
+		},
+		expectedRawData: map[string]interface{}{
+			"status": map[string]interface{}{
+				"endpoints": map[string]interface{}{
+					"primaryHost": "primary.example.com",
+				},
+			},
+		},
+	}))
+
+	t.Run("should return value selected by array index", assertHandler(args{
+		name:  "service.binding",
+		value: "path={.status.endpoints[1].host}",
+		service: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"namespace": "the-namespace",
+			},
+			"status": map[string]interface{}{
+				"endpoints": []interface{}{
+					map[string]interface{}{"type": "replica", "host": "replica.example.com"},
+					map[string]interface{}{"type": "primary", "host": "primary.example.com"},
+				},
+			},
+		},
+		expectedData: map[string]interface{}{
+			"endpoints_1_host": "primary.example.com",
+		},
+		expectedRawData: map[string]interface{}{
+			"status": map[string]interface{}{
+				"endpoints": map[string]interface{}{
+					"endpoints_1_host": "primary.example.com",
+				},
+			},
+		},
+	}))
+
+	t.Run("should name values selected by different array indexes apart", func(t *testing.T) {
+		service := map[string]interface{}{
+			"status": map[string]interface{}{
+				"hosts": []interface{}{"primary.example.com", "replica.example.com"},
+			},
+		}
+		data := map[string]interface{}{}
+		for _, value := range []string{"path={.status.hosts[0]}", "path={.status.hosts[1]}"} {
+			handler, err := NewSpecHandler(
+				mocks.NewFake(t, "test").FakeDynClient(),
+				"service.binding",
+				value,
+				unstructured.Unstructured{Object: service},
+				testutils.BuildTestRESTMapper(),
+				nil,
+			)
+			require.NoError(t, err)
+			got, err := handler.Handle()
+			require.NoError(t, err)
+			for k, v := range got.Data {
+				data[k] = v
+			}
+		}
+		require.Equal(t, map[string]interface{}{
+			"hosts_0": "primary.example.com",
+			"hosts_1": "replica.example.com",
+		}, data)
+	})
+
+	t.Run("should require a name for values selected by a filter", func(t *testing.T) {
+		handler, err := NewSpecHandler(
+			mocks.NewFake(t, "test").FakeDynClient(),
+			"service.binding",
+			`path={.status.endpoints[?(@.type=="primary")].host}`,
+			unstructured.Unstructured{Object: map[string]interface{}{}},
+			testutils.BuildTestRESTMapper(),
+			nil,
+		)
+		require.NoError(t, err)
+		_, err = handler.Handle()
+		require.Error(t, err)
+	})
+}
+
+func TestSpecHandlerObjectReferences(t *testing.T) {
+	service := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"namespace": "the-namespace",
+		},
+		"status": map[string]interface{}{
+			"serviceName": "the-service",
+			"secretName":  "the-secret",
+		},
+	}
+
+	f := mocks.NewFake(t, "the-namespace")
+	f.AddMockResource(&corev1.Service{
+		TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "the-namespace",
+			Name:      "the-service",
+		},
+		Spec: corev1.ServiceSpec{ClusterIP: "10.0.0.12"},
+	})
+	f.AddMockResource(&corev1.Secret{
+		TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "the-namespace",
+			Name:      "the-secret",
+		},
+		Data: map[string][]byte{
+			"tls.crt": []byte("certificate"),
+		},
+	})
+
+	handle := func(t *testing.T, name, value string) (*SpecHandler, result, error) {
+		h, err := NewSpecHandler(
+			f.FakeDynClient(),
+			name,
+			value,
+			unstructured.Unstructured{Object: service},
+			testutils.BuildTestRESTMapper(),
+			nil,
+		)
+		require.NoError(t, err)
+		r, err := h.Handle()
+		return h, r, err
+	}
+
+	t.Run("should return field from referenced object", func(t *testing.T) {
+		h, got, err := handle(t,
+			"service.binding/host",
+			"path={.status.serviceName},objectType=Service,sourcePath={.spec.clusterIP}",
+		)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"host": "10.0.0.12"}, got.Data)
+		require.Equal(t, []ObjectReference{{
+			GroupVersionKind: corev1.SchemeGroupVersion.WithKind("Service"),
+			Namespace:        "the-namespace",
+			Name:             "the-service",
+		}}, h.References())
+	})
+
+	t.Run("should decode data from referenced secret", func(t *testing.T) {
+		_, got, err := handle(t,
+			"service.binding/certificate",
+			"path={.status.secretName},objectType=v1/Secret,sourcePath={.data['tls.crt']}",
+		)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"certificate": "certificate"}, got.Data)
+	})
+
+	t.Run("should record reference when referenced object does not exist", func(t *testing.T) {
+		service["status"].(map[string]interface{})["serviceName"] = "missing-service"
+		h, _, err := handle(t,
+			"service.binding/host",
+			"path={.status.serviceName},objectType=Service,sourcePath={.spec.clusterIP}",
+		)
+		require.Error(t, err)
+		require.Equal(t, []ObjectReference{{
+			GroupVersionKind: corev1.SchemeGroupVersion.WithKind("Service"),
+			Namespace:        "the-namespace",
+			Name:             "missing-service",
+		}}, h.References())
+	})
+
+	t.Run("should require sourcePath for arbitrary object types", func(t *testing.T) {
+		_, _, err := handle(t,
+			"service.binding/host",
+			"path={.status.serviceName},objectType=Service",
+		)
+		require.Error(t, err)
+	})
+}
+
+func TestSpecHandlerMissingValues(t *testing.T) {
+	service := unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"namespace": "the-namespace",
+		},
+		"status": map[string]interface{}{
+			"host": "db.example.com",
+		},
+	}}
+
+	handle := func(name, value string) (result, error) {
+		h, err := NewSpecHandler(
+			mocks.NewFake(t, "the-namespace").FakeDynClient(),
+			name,
+			value,
+			service,
+			testutils.BuildTestRESTMapper(),
+			nil,
+		)
+		require.NoError(t, err)
+		return h.Handle()
+	}
+
+	t.Run("should use default value when element is not found", func(t *testing.T) {
+		got, err := handle("service.binding/port", "path={.status.port},default=5432")
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"port": "5432"}, got.Data)
+		require.Equal(t, map[string]interface{}{
+			"status": map[string]interface{}{"port": "5432"},
+		}, got.RawData)
+	})
+
+	t.Run("should not use default value when element is found", func(t *testing.T) {
+		got, err := handle("service.binding/host", "path={.status.host},default='localhost'")
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"host": "db.example.com"}, got.Data)
+	})
+
+	t.Run("should use default value when the referenced secret is not found", func(t *testing.T) {
+		got, err := handle("service.binding/password",
+			"path={.status.host},objectType=Secret,sourceKey=password,default=hunter2")
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"password": "hunter2"}, got.Data)
+	})
+
+	t.Run("should unquote default value", func(t *testing.T) {
+		got, err := handle("service.binding/options", "path={.status.options},default='a=1,b=2'")
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"options": "a=1,b=2"}, got.Data)
+	})
+
+	for _, tc := range []struct {
+		value    string
+		optional bool
+		required bool
+		path     string
+	}{
+		{value: "path={.status.password}"},
+		{value: "path={.status.password},optional=true", optional: true},
+		{value: "path={.status.password},required=true", required: true},
+		{value: "path={.status.host},objectType=Secret,sourceKey=password", path: "{.status.host}"},
+		{value: "path={.status.host},objectType=Secret,sourceKey=password,optional=true", path: "{.status.host}", optional: true},
+	} {
+		t.Run("should return missing value error: "+tc.value, func(t *testing.T) {
+			_, err := handle("service.binding/password", tc.value)
+			path := tc.path
+			if path == "" {
+				path = "{.status.password}"
+			}
+			require.Equal(t, &ErrMissingValue{
+				Key:      "service.binding/password",
+				Path:     path,
+				Optional: tc.optional,
+				Required: tc.required,
+			}, err)
+		})
+	}
+
+	for _, value := range []string{
+		"path={.status.password},optional=maybe",
+		"path={.status.password},optional=true,required=true",
+		"path={.status.password},required=true,default=hunter2",
+		"path={.status.credentials},objectType=Secret,default=hunter2",
+		"path={.status.hosts},elementType=sliceOfStrings,default=localhost",
+	} {
+		t.Run("should reject invalid markers: "+value, func(t *testing.T) {
+			_, err := handle("service.binding/password", value)
+			require.Error(t, err)
+			_, isMissing := err.(*ErrMissingValue)
+			require.False(t, isMissing)
+		})
+	}
 }
@@ -0,0 +1,168 @@
+package servicebinding
+
+import (
+	"context"
+
+	olmv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/config"
+)
+
+// cachedResources are the resources read from the informer cache, instead of the API server, while
+// reconciling: CRDs and CSVs describing services, and the resources services may own. Secrets and
+// ConfigMaps are left out, since caching them would hold every Secret and ConfigMap of the watched
+// namespaces in memory; they are always read from the API server.
+var cachedResources = []schema.GroupVersionResource{
+	crdGVR,
+	crdV1beta1GVR,
+	olmv1alpha1.SchemeGroupVersion.WithResource(csvResource),
+	corev1.SchemeGroupVersion.WithResource("services"),
+	{Group: "route.openshift.io", Version: "v1", Resource: "routes"},
+}
+
+// cachedClient is a dynamic.Interface which serves Get and List calls for cachedResources from the
+// informer cache; all other calls, writes in particular, reach the API server.
+type cachedClient struct {
+	dynamic.Interface
+	reader     client.Reader
+	restMapper meta.RESTMapper
+	// watchNamespaces are the namespaces the informer cache is restricted to; reads from other
+	// namespaces reach the API server. Empty when the cache holds all namespaces.
+	watchNamespaces watchNamespaces
+	cached          map[schema.GroupVersionResource]bool
+}
+
+var _ dynamic.Interface = (*cachedClient)(nil)
+
+// newCachedClient returns a dynamic client reading cachedResources from reader.
+func newCachedClient(
+	dynClient dynamic.Interface,
+	reader client.Reader,
+	restMapper meta.RESTMapper,
+	watchNamespaces watchNamespaces,
+) dynamic.Interface {
+	cached := make(map[schema.GroupVersionResource]bool, len(cachedResources))
+	for _, gvr := range cachedResources {
+		cached[gvr] = true
+	}
+	return &cachedClient{
+		Interface:       dynClient,
+		reader:          reader,
+		restMapper:      restMapper,
+		watchNamespaces: watchNamespaces,
+		cached:          cached,
+	}
+}
+
+// uncached returns the client reaching the API server behind the given client, or the client itself
+// when it doesn't read from the informer cache.
+func uncached(c dynamic.Interface) dynamic.Interface {
+	if cc, ok := c.(*cachedClient); ok {
+		return cc.Interface
+	}
+	return c
+}
+
+func (c *cachedClient) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	resourceClient := c.Interface.Resource(gvr)
+	if !c.cached[gvr] || !config.Get().FeatureEnabled(config.CachedReadsGate) {
+		return resourceClient
+	}
+	// resources unknown to the cluster, e.g. routes outside OpenShift, can't be cached
+	gvk, err := c.restMapper.KindFor(gvr)
+	if err != nil {
+		return resourceClient
+	}
+	return &cachedNamespaceableResourceClient{
+		cachedResourceClient: &cachedResourceClient{
+			ResourceInterface: resourceClient,
+			client:            c,
+			gvk:               gvk,
+		},
+		resourceClient: resourceClient,
+	}
+}
+
+// cachedNamespaceableResourceClient reads a cached resource from the informer cache.
+type cachedNamespaceableResourceClient struct {
+	*cachedResourceClient
+	resourceClient dynamic.NamespaceableResourceInterface
+}
+
+func (c *cachedNamespaceableResourceClient) Namespace(ns string) dynamic.ResourceInterface {
+	return &cachedResourceClient{
+		ResourceInterface: c.resourceClient.Namespace(ns),
+		client:            c.client,
+		gvk:               c.gvk,
+		namespace:         ns,
+	}
+}
+
+// cachedResourceClient reads a cached resource, optionally in a namespace, from the informer cache.
+type cachedResourceClient struct {
+	// ResourceInterface is the API server client, handling all calls but reads.
+	dynamic.ResourceInterface
+	client    *cachedClient
+	gvk       schema.GroupVersionKind
+	namespace string
+}
+
+// isCached returns whether objects in the client's namespace are found in the informer cache.
+func (c *cachedResourceClient) isCached() bool {
+	return c.client.watchNamespaces.contains(c.namespace)
+}
+
+func (c *cachedResourceClient) Get(
+	name string,
+	options metav1.GetOptions,
+	subresources ...string,
+) (*unstructured.Unstructured, error) {
+	if !c.isCached() || len(subresources) > 0 || options.ResourceVersion != "" {
+		return c.ResourceInterface.Get(name, options, subresources...)
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(c.gvk)
+	key := client.ObjectKey{Namespace: c.namespace, Name: name}
+	if err := c.client.reader.Get(context.TODO(), key, u); err != nil {
+		if _, ok := err.(*cache.ErrCacheNotStarted); ok {
+			return c.ResourceInterface.Get(name, options)
+		}
+		return nil, err
+	}
+	return u, nil
+}
+
+func (c *cachedResourceClient) List(opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	if !c.isCached() || opts.FieldSelector != "" || opts.ResourceVersion != "" {
+		return c.ResourceInterface.List(opts)
+	}
+
+	selector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(c.gvk.GroupVersion().WithKind(c.gvk.Kind + "List"))
+	err = c.client.reader.List(context.TODO(), list,
+		client.InNamespace(c.namespace),
+		client.MatchingLabelsSelector{Selector: selector},
+	)
+	if err != nil {
+		if _, ok := err.(*cache.ErrCacheNotStarted); ok {
+			return c.ResourceInterface.List(opts)
+		}
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,233 @@
+package servicebinding
+
+import (
+	"context"
+	"testing"
+
+	olmv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/testutils"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+// dynamicReader is a client.Reader standing in for the informer cache, reading unstructured objects
+// from a dynamic client.
+type dynamicReader struct {
+	client     dynamic.Interface
+	restMapper meta.RESTMapper
+}
+
+var _ client.Reader = (*dynamicReader)(nil)
+
+func (r *dynamicReader) resource(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+	mapping, err := r.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	return mapping.Resource, nil
+}
+
+func (r *dynamicReader) Get(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+	u := obj.(*unstructured.Unstructured)
+	gvr, err := r.resource(u.GroupVersionKind())
+	if err != nil {
+		return err
+	}
+	found, err := r.client.Resource(gvr).Namespace(key.Namespace).Get(key.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	found.DeepCopyInto(u)
+	return nil
+}
+
+func (r *dynamicReader) List(_ context.Context, list runtime.Object, opts ...client.ListOption) error {
+	ul := list.(*unstructured.UnstructuredList)
+	gvk := ul.GroupVersionKind()
+	gvr, err := r.resource(gvk.GroupVersion().WithKind(gvk.Kind[:len(gvk.Kind)-len("List")]))
+	if err != nil {
+		return err
+	}
+	listOpts := (&client.ListOptions{}).ApplyOptions(opts)
+	found, err := r.client.Resource(gvr).Namespace(listOpts.Namespace).List(*listOpts.AsListOptions())
+	if err != nil {
+		return err
+	}
+	found.DeepCopyInto(ul)
+	return nil
+}
+
+// buildCachedClientTestRESTMapper returns a RESTMapper also aware of CRDs, CSVs and routes.
+func buildCachedClientTestRESTMapper() meta.RESTMapper {
+	restMapper := testutils.BuildTestRESTMapper().(*meta.DefaultRESTMapper)
+	restMapper.Add(crdGVR.GroupVersion().WithKind("CustomResourceDefinition"), meta.RESTScopeRoot)
+	restMapper.Add(crdV1beta1GVR.GroupVersion().WithKind("CustomResourceDefinition"), meta.RESTScopeRoot)
+	restMapper.Add(olmv1alpha1.SchemeGroupVersion.WithKind(olmv1alpha1.ClusterServiceVersionKind), meta.RESTScopeNamespace)
+	restMapper.Add(schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"}, meta.RESTScopeNamespace)
+	return restMapper
+}
+
+// countReads returns the number of get and list calls, per resource, the fake client has served.
+func countReads(client *fakedynamic.FakeDynamicClient) map[string]int {
+	reads := make(map[string]int)
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "get" || action.GetVerb() == "list" {
+			reads[action.GetResource().Resource]++
+		}
+	}
+	return reads
+}
+
+func sumReads(reads map[string]int) int {
+	total := 0
+	for _, n := range reads {
+		total += n
+	}
+	return total
+}
+
+func TestCachedClientReconcileAPICalls(t *testing.T) {
+	backingServiceResourceRef := "test-cached"
+	matchLabels := map[string]string{
+		"connects-to": "database",
+		"environment": "reconciler",
+	}
+
+	f := mocks.NewFake(t, reconcilerNs)
+	f.AddMockedUnstructuredServiceBinding(reconcilerName, backingServiceResourceRef, reconcilerName, deploymentsGVR, matchLabels)
+	f.AddMockedUnstructuredCSV("cluster-service-version-list")
+	f.AddMockedUnstructuredDatabaseCRD()
+	f.AddMockedUnstructuredDatabaseCR(backingServiceResourceRef)
+	f.AddMockedUnstructuredDeployment(reconcilerName, matchLabels)
+	f.AddMockedUnstructuredSecret("db-credentials")
+
+	mapper := buildCachedClientTestRESTMapper()
+	reconcile := func(dynClient dynamic.Interface) {
+		r := &reconciler{dynClient: dynClient, restMapper: mapper, scheme: f.S}
+		r.resourceWatcher = newFakeResourceWatcher(mapper)
+		_, err := r.Reconcile(reconcileRequest())
+		require.NoError(t, err)
+	}
+
+	uncachedClient := f.FakeDynClient()
+	reconcile(uncachedClient)
+	uncachedReads := countReads(uncachedClient)
+
+	apiClient := f.FakeDynClient()
+	reader := &dynamicReader{client: f.FakeDynClient(), restMapper: mapper}
+	reconcile(newCachedClient(apiClient, reader, mapper, nil))
+	cachedReads := countReads(apiClient)
+
+	for _, gvr := range cachedResources {
+		require.Zero(t, cachedReads[gvr.Resource], "%s should be read from the cache", gvr.Resource)
+	}
+	// secrets, the intermediary secret included, are always read from the API server
+	require.NotZero(t, cachedReads["secrets"])
+	require.Equal(t, uncachedReads["secrets"], cachedReads["secrets"])
+	require.NotZero(t, uncachedReads["customresourcedefinitions"])
+	require.NotZero(t, uncachedReads[csvResource])
+	require.NotZero(t, uncachedReads["secrets"])
+	require.Less(t, sumReads(cachedReads), sumReads(uncachedReads))
+	// the ServiceBinding itself is always read from the API server
+	require.Equal(t, uncachedReads["servicebindings"], cachedReads["servicebindings"])
+
+	// writes reach the API server
+	created := false
+	for _, action := range apiClient.Actions() {
+		if action.GetVerb() == "create" && action.GetResource().Resource == "secrets" {
+			created = true
+		}
+	}
+	require.True(t, created, "intermediary secret should be created through the API server")
+}
+
+func TestCachedClientDelegation(t *testing.T) {
+	ns := "cached-ns"
+	f := mocks.NewFake(t, ns)
+	for _, svcNs := range []string{ns, "other-ns"} {
+		svc := &unstructured.Unstructured{}
+		svc.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Service"))
+		svc.SetNamespace(svcNs)
+		svc.SetName("db")
+		f.AddMockResource(svc)
+	}
+	f.AddMockedUnstructuredSecret("db-credentials")
+
+	mapper := buildCachedClientTestRESTMapper()
+	servicesGVR := corev1.SchemeGroupVersion.WithResource("services")
+	secretsGVR := corev1.SchemeGroupVersion.WithResource("secrets")
+	deploymentsGVR := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	tests := []struct {
+		name     string
+		call     func(c dynamic.Interface) error
+		apiReads int
+	}{
+		{
+			name: "get is served from the cache",
+			call: func(c dynamic.Interface) error {
+				_, err := c.Resource(servicesGVR).Namespace(ns).Get("db", metav1.GetOptions{})
+				return err
+			},
+		},
+		{
+			name: "list with label selector is served from the cache",
+			call: func(c dynamic.Interface) error {
+				_, err := c.Resource(servicesGVR).Namespace(ns).List(metav1.ListOptions{LabelSelector: "app=db"})
+				return err
+			},
+		},
+		{
+			name: "list with field selector reaches the API server",
+			call: func(c dynamic.Interface) error {
+				_, err := c.Resource(servicesGVR).Namespace(ns).List(metav1.ListOptions{FieldSelector: "metadata.name=x"})
+				return err
+			},
+			apiReads: 1,
+		},
+		{
+			name: "get outside of the watched namespace reaches the API server",
+			call: func(c dynamic.Interface) error {
+				_, err := c.Resource(servicesGVR).Namespace("other-ns").Get("db", metav1.GetOptions{})
+				return err
+			},
+			apiReads: 1,
+		},
+		{
+			name: "secrets reach the API server",
+			call: func(c dynamic.Interface) error {
+				_, err := c.Resource(secretsGVR).Namespace(ns).Get("db-credentials", metav1.GetOptions{})
+				return err
+			},
+			apiReads: 1,
+		},
+		{
+			name: "uncached resources reach the API server",
+			call: func(c dynamic.Interface) error {
+				_, err := c.Resource(deploymentsGVR).Namespace(ns).List(metav1.ListOptions{})
+				return err
+			},
+			apiReads: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiClient := f.FakeDynClient()
+			reader := &dynamicReader{client: f.FakeDynClient(), restMapper: mapper}
+			c := newCachedClient(apiClient, reader, mapper, watchNamespaces{ns, "other"})
+			require.NoError(t, tt.call(c))
+			require.Equal(t, tt.apiReads, sumReads(countReads(apiClient)))
+		})
+	}
+}
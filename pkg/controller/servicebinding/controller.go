@@ -1,10 +1,17 @@
 package servicebinding
 
 import (
+	"errors"
+	"os"
+
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/config"
+	"github.com/redhat-developer/service-binding-operator/pkg/sharding"
 )
 
 // Add creates a new ServiceBinding Controller and adds it to the Manager. The Manager will
@@ -21,24 +28,120 @@ func Add(mgr manager.Manager) error {
 	return add(mgr, r, client)
 }
 
-// newReconciler returns a new reconcile.Reconciler
+// newReconciler returns a new reconcile.Reconciler, reading services and their related resources
+// from the manager's informer cache.
 func newReconciler(mgr manager.Manager, client dynamic.Interface) (*reconciler, error) {
 	return &reconciler{
-		dynClient:  client,
-		scheme:     mgr.GetScheme(),
-		restMapper: mgr.GetRESTMapper(),
+		dynClient:    newCachedClient(client, mgr.GetCache(), mgr.GetRESTMapper(), getWatchNamespaces()),
+		scheme:       mgr.GetScheme(),
+		restMapper:   mgr.GetRESTMapper(),
+		dependencies: newDependencyTracker(),
+		pending:      newPendingKindTracker(),
+		recorder:     mgr.GetEventRecorderFor(controllerName),
+		outcomes:     newOutcomeTracker(),
 	}, nil
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler.
 func add(mgr manager.Manager, r *reconciler, client dynamic.Interface) error {
-	opts := controller.Options{Reconciler: r}
+	opts := controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: config.Get().MaxConcurrentReconciles,
+	}
 	c, err := NewSBRController(mgr, opts, client)
 	if err != nil {
 		return err
 	}
+	c.dependencies = r.dependencies
+	c.pending = r.pending
 	r.resourceWatcher = c
-	return c.Watch()
+	if shardConfig := config.Get().Sharding; shardConfig.Enabled {
+		membership, err := joinShard(mgr, c, client, shardConfig)
+		if err != nil {
+			return err
+		}
+		r.shard = membership
+		c.shard = membership
+	}
+	if err := c.Watch(); err != nil {
+		return err
+	}
+	if err := addHealthChecks(mgr, c); err != nil {
+		return err
+	}
+	if addr := config.Get().DebugAddress; addr != "" {
+		if err := addDebugServer(mgr, c, r.outcomes, addr); err != nil {
+			return err
+		}
+	}
+	return addBindableResourcesInformer(mgr, c, client)
+}
+
+// getOperatorNamespace returns the operator namespace; the first watched namespace when running
+// locally, which is empty when all namespaces are watched.
+func getOperatorNamespace() string {
+	ns, err := k8sutil.GetOperatorNamespace()
+	if err != nil {
+		return getWatchNamespaces().listed()[0]
+	}
+	return ns
+}
+
+// getShardIdentity returns the identity of the operator replica among the shard members: its pod
+// name, or its host name when running locally.
+func getShardIdentity() (string, error) {
+	if name := os.Getenv("POD_NAME"); name != "" {
+		return name, nil
+	}
+	return os.Hostname()
+}
+
+// joinShard makes the operator replica a member of the shard group, whose leases are held in the
+// operator namespace, and reconciles again the ServiceBindings it owns whenever the members change.
+func joinShard(
+	mgr manager.Manager,
+	c *sbrController,
+	client dynamic.Interface,
+	shardConfig config.Sharding,
+) (*sharding.Membership, error) {
+	ns := getOperatorNamespace()
+	if ns == "" {
+		return nil, errors.New("sharding requires the operator namespace to hold the shard leases")
+	}
+	identity, err := getShardIdentity()
+	if err != nil {
+		return nil, err
+	}
+	membership := sharding.NewMembership(client, ns, controllerName, identity, shardConfig.LeaseDuration)
+	if err := membership.Join(); err != nil {
+		return nil, err
+	}
+	c.logger.Info("Joined the shard group", "Identity", identity, "Members", membership.Members())
+	membership.OnChange(c.resyncAll)
+	return membership, mgr.Add(membership)
+}
+
+// addBindableResourcesInformer keeps the bindable resources in sync with the configuration ConfigMap
+// in the operator namespace.
+func addBindableResourcesInformer(mgr manager.Manager, c *sbrController, client dynamic.Interface) error {
+	// when running locally, the configuration is looked up in the first watched namespace
+	ns := getOperatorNamespace()
+	if ns == "" {
+		c.logger.Info("Operator namespace is unknown, using the default bindable resources")
+		return nil
+	}
+
+	informer := newBindableResourcesInformer(client, ns)
+	informer.AddEventHandler(&bindableResourcesHandler{
+		registry:   bindableResources,
+		restMapper: c.RestMapper,
+		onChange:   c.onBindableResourcesChange,
+		logger:     c.logger.WithName("bindableResources"),
+	})
+	return mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		informer.Run(stop)
+		return nil
+	}))
 }
 
 // blank assignment to verify that ReconcileServiceBinding implements reconcile.Reconciler
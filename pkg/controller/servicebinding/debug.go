@@ -0,0 +1,226 @@
+package servicebinding
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/log"
+)
+
+var debugLog = log.NewLog("debug")
+
+// debugBindingsPath is the path of the debug endpoint listing the ServiceBindings.
+const debugBindingsPath = "/debug/bindings"
+
+// reconcileOutcome is the outcome of the last reconciliation of a ServiceBinding.
+type reconcileOutcome struct {
+	Time   time.Time `json:"time"`
+	Result string    `json:"result"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// outcomeTracker keeps the outcome of the last reconciliation of each ServiceBinding. A nil
+// outcomeTracker ignores all operations.
+type outcomeTracker struct {
+	mu       sync.RWMutex
+	outcomes map[types.NamespacedName]reconcileOutcome
+}
+
+// newOutcomeTracker returns an empty outcomeTracker.
+func newOutcomeTracker() *outcomeTracker {
+	return &outcomeTracker{outcomes: make(map[types.NamespacedName]reconcileOutcome)}
+}
+
+// record records the outcome of a reconciliation of the given ServiceBinding.
+func (t *outcomeTracker) record(sbr types.NamespacedName, res reconcile.Result, err error) {
+	if t == nil {
+		return
+	}
+	outcome := reconcileOutcome{Time: time.Now(), Result: reconcileResult(res, err)}
+	if err != nil {
+		outcome.Error = err.Error()
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.outcomes[sbr] = outcome
+}
+
+// forget removes the outcome of the given ServiceBinding.
+func (t *outcomeTracker) forget(sbr types.NamespacedName) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.outcomes, sbr)
+}
+
+// get returns the outcome of the last reconciliation of the given ServiceBinding, if any.
+func (t *outcomeTracker) get(sbr types.NamespacedName) *reconcileOutcome {
+	if t == nil {
+		return nil
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if outcome, ok := t.outcomes[sbr]; ok {
+		return &outcome
+	}
+	return nil
+}
+
+// debugObject references an object in the debug endpoints.
+type debugObject struct {
+	Group     string `json:"group"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// debugCondition is a ServiceBinding condition in the debug endpoints.
+type debugCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// debugBinding describes a ServiceBinding in the debug endpoints; it holds no binding value.
+type debugBinding struct {
+	Namespace     string            `json:"namespace"`
+	Name          string            `json:"name"`
+	Services      []debugObject     `json:"services"`
+	Workloads     []debugObject     `json:"workloads"`
+	Secret        string            `json:"secret,omitempty"`
+	Conditions    []debugCondition  `json:"conditions"`
+	LastReconcile *reconcileOutcome `json:"lastReconcile,omitempty"`
+}
+
+// bindingsDebugHandler lists the ServiceBindings reconciled by the operator replica, with their
+// resolved services, bound workloads and last reconciliation outcome; the namespace query
+// parameter restricts the list to a namespace. Only object references are listed, never the
+// content of secrets.
+type bindingsDebugHandler struct {
+	controller *sbrController
+	outcomes   *outcomeTracker
+}
+
+// ServeHTTP implements http.Handler.
+func (h *bindingsDebugHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ns := req.URL.Query().Get("namespace")
+	bindings := make([]debugBinding, 0)
+	for _, obj := range h.controller.index.list() {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		if ns != "" && u.GetNamespace() != ns || !ownsNamespace(h.controller.shard, u.GetNamespace()) {
+			continue
+		}
+		sbr, err := convertToSBR(u.Object)
+		if err != nil {
+			debugLog.Error(err, "on converting ServiceBinding", "Namespace", u.GetNamespace(), "Name", u.GetName())
+			continue
+		}
+		bindings = append(bindings, h.describe(sbr))
+	}
+	sort.Slice(bindings, func(i, j int) bool {
+		if bindings[i].Namespace != bindings[j].Namespace {
+			return bindings[i].Namespace < bindings[j].Namespace
+		}
+		return bindings[i].Name < bindings[j].Name
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(bindings); err != nil {
+		debugLog.Error(err, "on writing the ServiceBindings")
+	}
+}
+
+// describe returns the description of the given ServiceBinding.
+func (h *bindingsDebugHandler) describe(sbr *v1alpha1.ServiceBinding) debugBinding {
+	b := debugBinding{
+		Namespace:     sbr.GetNamespace(),
+		Name:          sbr.GetName(),
+		Services:      make([]debugObject, 0, len(sbr.Spec.Services)),
+		Workloads:     make([]debugObject, 0, len(sbr.Status.Applications)),
+		Secret:        sbr.Status.Secret,
+		Conditions:    make([]debugCondition, 0, len(sbr.Status.Conditions)),
+		LastReconcile: h.outcomes.get(types.NamespacedName{Namespace: sbr.GetNamespace(), Name: sbr.GetName()}),
+	}
+	for _, svc := range sbr.Spec.Services {
+		ns := sbr.GetNamespace()
+		if svc.Namespace != nil {
+			ns = *svc.Namespace
+		}
+		b.Services = append(b.Services, debugObject{
+			Group:     svc.Group,
+			Version:   svc.Version,
+			Kind:      svc.Kind,
+			Namespace: ns,
+			Name:      svc.Name,
+		})
+	}
+	for _, app := range sbr.Status.Applications {
+		b.Workloads = append(b.Workloads, debugObject{
+			Group:     app.Group,
+			Version:   app.Version,
+			Kind:      app.Kind,
+			Namespace: sbr.GetNamespace(),
+			Name:      app.Name,
+		})
+	}
+	for _, c := range sbr.Status.Conditions {
+		b.Conditions = append(b.Conditions, debugCondition{
+			Type:    string(c.Type),
+			Status:  string(c.Status),
+			Reason:  c.Reason,
+			Message: c.Message,
+		})
+	}
+	return b
+}
+
+// debugListenAddress returns the address the debug endpoints are served at: the given address, on
+// localhost when it has no host, as the endpoints aren't authenticated.
+func debugListenAddress(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || host != "" {
+		return addr
+	}
+	return net.JoinHostPort("127.0.0.1", port)
+}
+
+// addDebugServer serves the debug endpoints at the given address while the manager runs.
+func addDebugServer(mgr manager.Manager, c *sbrController, outcomes *outcomeTracker, addr string) error {
+	addr = debugListenAddress(addr)
+	mux := http.NewServeMux()
+	mux.Handle(debugBindingsPath, &bindingsDebugHandler{controller: c, outcomes: outcomes})
+	server := &http.Server{Addr: addr, Handler: mux}
+	return mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		go func() {
+			<-stop
+			if err := server.Shutdown(context.Background()); err != nil {
+				debugLog.Error(err, "on stopping the debug server")
+			}
+		}()
+		debugLog.Info("Serving debug endpoints", "Address", addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	}))
+}
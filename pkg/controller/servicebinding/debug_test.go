@@ -0,0 +1,136 @@
+package servicebinding
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+)
+
+func TestOutcomeTracker(t *testing.T) {
+	sbr := types.NamespacedName{Namespace: "ns", Name: "sbr"}
+	tracker := newOutcomeTracker()
+	require.Nil(t, tracker.get(sbr))
+
+	tracker.record(sbr, reconcile.Result{}, errors.New("no such service"))
+	outcome := tracker.get(sbr)
+	require.NotNil(t, outcome)
+	require.Equal(t, errorResult, outcome.Result)
+	require.Equal(t, "no such service", outcome.Error)
+
+	tracker.record(sbr, reconcile.Result{}, nil)
+	require.Equal(t, successResult, tracker.get(sbr).Result)
+	require.Empty(t, tracker.get(sbr).Error)
+
+	tracker.forget(sbr)
+	require.Nil(t, tracker.get(sbr))
+
+	var nilTracker *outcomeTracker
+	nilTracker.record(sbr, reconcile.Result{}, nil)
+	require.Nil(t, nilTracker.get(sbr))
+}
+
+func TestDebugListenAddress(t *testing.T) {
+	require.Equal(t, "127.0.0.1:8082", debugListenAddress(":8082"))
+	require.Equal(t, "0.0.0.0:8082", debugListenAddress("0.0.0.0:8082"))
+	require.Equal(t, "[::1]:8082", debugListenAddress("[::1]:8082"))
+}
+
+func TestBindingsDebugHandler(t *testing.T) {
+	otherNs := "db"
+	sbrs := []*v1alpha1.ServiceBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-b", Name: "sbr"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-a", Name: "sbr"},
+			Spec: v1alpha1.ServiceBindingSpec{
+				Services: []v1alpha1.Service{
+					{
+						GroupVersionKind:     metav1.GroupVersionKind{Group: "postgresql.baiju.dev", Version: "v1alpha1", Kind: "Database"},
+						LocalObjectReference: corev1.LocalObjectReference{Name: "db"},
+					},
+					{
+						GroupVersionKind:     metav1.GroupVersionKind{Version: "v1", Kind: "Service"},
+						LocalObjectReference: corev1.LocalObjectReference{Name: "db"},
+						Namespace:            &otherNs,
+					},
+				},
+			},
+			Status: v1alpha1.ServiceBindingStatus{
+				Secret: "sbr-secret",
+				Applications: []v1alpha1.BoundApplication{
+					{
+						GroupVersionKind:     metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+						LocalObjectReference: corev1.LocalObjectReference{Name: "app"},
+					},
+				},
+				Conditions: []conditionsv1.Condition{
+					{Type: conditionsv1.ConditionType("Ready"), Status: corev1.ConditionTrue},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-c", Name: "sbr"},
+		},
+	}
+	outcomes := newOutcomeTracker()
+	outcomes.record(types.NamespacedName{Namespace: "tenant-a", Name: "sbr"}, reconcile.Result{}, nil)
+	handler := &bindingsDebugHandler{
+		controller: &sbrController{
+			index: newTestServiceBindingIndex(t, sbrs...),
+			shard: namespaceShard{"tenant-a": true, "tenant-b": true},
+		},
+		outcomes: outcomes,
+	}
+
+	get := func(url string) []debugBinding {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var bindings []debugBinding
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &bindings))
+		return bindings
+	}
+
+	t.Run("lists the owned bindings", func(t *testing.T) {
+		bindings := get(debugBindingsPath)
+		require.Len(t, bindings, 2, "tenant-c is owned by another replica")
+		require.Equal(t, "tenant-a", bindings[0].Namespace)
+		require.Equal(t, "tenant-b", bindings[1].Namespace)
+		require.Nil(t, bindings[1].LastReconcile)
+		require.Empty(t, bindings[1].Services)
+
+		b := bindings[0]
+		require.Equal(t, []debugObject{
+			{Group: "postgresql.baiju.dev", Version: "v1alpha1", Kind: "Database", Namespace: "tenant-a", Name: "db"},
+			{Version: "v1", Kind: "Service", Namespace: "db", Name: "db"},
+		}, b.Services)
+		require.Equal(t, []debugObject{
+			{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "tenant-a", Name: "app"},
+		}, b.Workloads)
+		require.Equal(t, "sbr-secret", b.Secret)
+		require.Equal(t, []debugCondition{{Type: "Ready", Status: "True"}}, b.Conditions)
+		require.NotNil(t, b.LastReconcile)
+		require.Equal(t, successResult, b.LastReconcile.Result)
+	})
+
+	t.Run("filters by namespace", func(t *testing.T) {
+		bindings := get(debugBindingsPath + "?namespace=tenant-b")
+		require.Len(t, bindings, 1)
+		require.Equal(t, "tenant-b", bindings[0].Namespace)
+
+		require.Empty(t, get(debugBindingsPath+"?namespace=tenant-c"))
+	})
+}
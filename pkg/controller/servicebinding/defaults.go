@@ -0,0 +1,83 @@
+package servicebinding
+
+import (
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/config"
+)
+
+// serviceBindingDefaultsName is the name of the ServiceBindingDefaults applied to the
+// ServiceBindings of its namespace.
+const serviceBindingDefaultsName = "default"
+
+// serviceBindingDefaultsResource is the ServiceBindingDefaults resource.
+var serviceBindingDefaultsResource = v1alpha1.SchemeGroupVersion.WithResource("servicebindingdefaults")
+
+// getServiceBindingDefaults returns the ServiceBindingDefaults of the given namespace, or nil when
+// there's none.
+func getServiceBindingDefaults(
+	client dynamic.Interface,
+	ns string,
+) (*v1alpha1.ServiceBindingDefaults, error) {
+	u, err := client.Resource(serviceBindingDefaultsResource).Namespace(ns).
+		Get(serviceBindingDefaultsName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defaults := &v1alpha1.ServiceBindingDefaults{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, defaults); err != nil {
+		return nil, err
+	}
+	return defaults, nil
+}
+
+// applyDefaults sets the fields the ServiceBinding spec leaves unset from the given namespace
+// defaults, when there are some, then from the operator configuration, and records the resulting
+// settings in the ServiceBinding status.
+func applyDefaults(sbr *v1alpha1.ServiceBinding, defaults *v1alpha1.ServiceBindingDefaults) {
+	spec := &sbr.Spec
+	effective := &v1alpha1.EffectiveSettings{}
+
+	if defaults != nil {
+		effective.Defaults = defaults.GetName()
+		if spec.MountPathPrefix == "" {
+			spec.MountPathPrefix = defaults.Spec.MountPathPrefix
+		}
+		if spec.EnvVarPrefix == "" {
+			spec.EnvVarPrefix = defaults.Spec.EnvVarPrefix
+		}
+		if spec.DetectBindingResources == nil && defaults.Spec.DetectBindingResources != nil {
+			detect := *defaults.Spec.DetectBindingResources
+			spec.DetectBindingResources = &detect
+		}
+		// the binding path is taken as a whole, since a path declaring only the secret path binds
+		// no container
+		if spec.Application != nil && spec.Application.BindingPath == nil {
+			spec.Application.BindingPath = defaults.Spec.BindingPath.DeepCopy()
+		}
+	}
+
+	if spec.MountPathPrefix == "" {
+		spec.MountPathPrefix = config.Get().DefaultMountPath
+	}
+	if spec.DetectBindingResources == nil {
+		falseBool := false
+		spec.DetectBindingResources = &falseBool
+	}
+	if spec.Application != nil {
+		ensureDefaults(spec.Application)
+		effective.BindingPath = spec.Application.BindingPath.DeepCopy()
+	}
+
+	effective.MountPathPrefix = spec.MountPathPrefix
+	effective.EnvVarPrefix = spec.EnvVarPrefix
+	effective.DetectBindingResources = *spec.DetectBindingResources
+	sbr.Status.Effective = effective
+}
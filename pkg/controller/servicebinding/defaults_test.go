@@ -0,0 +1,94 @@
+package servicebinding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/config"
+	"github.com/redhat-developer/service-binding-operator/pkg/converter"
+)
+
+func TestGetServiceBindingDefaults(t *testing.T) {
+	ns := "defaults"
+	client := fake.NewSimpleDynamicClient(runtime.NewScheme())
+
+	defaults, err := getServiceBindingDefaults(client, ns)
+	require.NoError(t, err)
+	require.Nil(t, defaults, "namespaces without defaults have none")
+
+	u, err := converter.ToUnstructuredAsGVK(&v1alpha1.ServiceBindingDefaults{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: serviceBindingDefaultsName},
+		Spec:       v1alpha1.ServiceBindingDefaultsSpec{EnvVarPrefix: "TEAM"},
+	}, v1alpha1.SchemeGroupVersion.WithKind("ServiceBindingDefaults"))
+	require.NoError(t, err)
+	_, err = client.Resource(serviceBindingDefaultsResource).Namespace(ns).Create(u, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	defaults, err = getServiceBindingDefaults(client, ns)
+	require.NoError(t, err)
+	require.NotNil(t, defaults)
+	require.Equal(t, "TEAM", defaults.Spec.EnvVarPrefix)
+}
+
+func TestApplyDefaults(t *testing.T) {
+	trueBool, falseBool := true, false
+	operatorDefaults := config.Get()
+	defaults := &v1alpha1.ServiceBindingDefaults{
+		ObjectMeta: metav1.ObjectMeta{Name: serviceBindingDefaultsName},
+		Spec: v1alpha1.ServiceBindingDefaultsSpec{
+			MountPathPrefix:        "/team",
+			EnvVarPrefix:           "TEAM",
+			DetectBindingResources: &trueBool,
+			BindingPath:            &v1alpha1.BindingPath{ContainersPath: "spec.containers"},
+		},
+	}
+
+	t.Run("explicit spec first", func(t *testing.T) {
+		sbr := &v1alpha1.ServiceBinding{Spec: v1alpha1.ServiceBindingSpec{
+			MountPathPrefix:        "/explicit",
+			EnvVarPrefix:           "EXPLICIT",
+			DetectBindingResources: &falseBool,
+			Application: &v1alpha1.Application{
+				BindingPath: &v1alpha1.BindingPath{SecretPath: "spec.secret"},
+			},
+		}}
+		applyDefaults(sbr, defaults)
+		require.Equal(t, &v1alpha1.EffectiveSettings{
+			MountPathPrefix:        "/explicit",
+			EnvVarPrefix:           "EXPLICIT",
+			DetectBindingResources: false,
+			BindingPath:            &v1alpha1.BindingPath{SecretPath: "spec.secret"},
+			Defaults:               serviceBindingDefaultsName,
+		}, sbr.Status.Effective)
+	})
+
+	t.Run("namespace defaults second", func(t *testing.T) {
+		sbr := &v1alpha1.ServiceBinding{Spec: v1alpha1.ServiceBindingSpec{Application: &v1alpha1.Application{}}}
+		applyDefaults(sbr, defaults)
+		require.Equal(t, &v1alpha1.EffectiveSettings{
+			MountPathPrefix:        "/team",
+			EnvVarPrefix:           "TEAM",
+			DetectBindingResources: true,
+			BindingPath:            &v1alpha1.BindingPath{ContainersPath: "spec.containers"},
+			Defaults:               serviceBindingDefaultsName,
+		}, sbr.Status.Effective)
+		require.Equal(t, "TEAM", sbr.Spec.EnvVarPrefix)
+		require.Equal(t, "spec.containers", sbr.Spec.Application.BindingPath.ContainersPath)
+		require.Equal(t, "spec.containers", defaults.Spec.BindingPath.ContainersPath, "defaults are left unchanged")
+	})
+
+	t.Run("operator defaults last", func(t *testing.T) {
+		sbr := &v1alpha1.ServiceBinding{Spec: v1alpha1.ServiceBindingSpec{Application: &v1alpha1.Application{}}}
+		applyDefaults(sbr, nil)
+		require.Equal(t, &v1alpha1.EffectiveSettings{
+			MountPathPrefix:        operatorDefaults.DefaultMountPath,
+			DetectBindingResources: false,
+			BindingPath:            &v1alpha1.BindingPath{ContainersPath: operatorDefaults.DefaultContainersPath},
+		}, sbr.Status.Effective)
+	})
+}
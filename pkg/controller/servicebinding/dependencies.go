@@ -0,0 +1,190 @@
+package servicebinding
+
+import (
+	"sync"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/controller/servicebinding/binding"
+)
+
+// dependencyTracker keeps track of the objects each Service Binding depends on: the objects read
+// while collecting its services, for example objects referenced by binding annotations, and the
+// resources owned by its services. It is used to map
+// events on those objects back to the Service Bindings depending on them. A nil dependencyTracker
+// ignores all operations.
+type dependencyTracker struct {
+	mu sync.RWMutex
+	// dependents maps an object to the Service Bindings depending on it.
+	dependents map[binding.ObjectReference]namespacedNameSet
+	// dependencies maps a Service Binding to the objects it depends on.
+	dependencies map[types.NamespacedName][]binding.ObjectReference
+}
+
+// newDependencyTracker returns an empty dependencyTracker.
+func newDependencyTracker() *dependencyTracker {
+	return &dependencyTracker{
+		dependents:   make(map[binding.ObjectReference]namespacedNameSet),
+		dependencies: make(map[types.NamespacedName][]binding.ObjectReference),
+	}
+}
+
+// track replaces the objects the given Service Binding depends on.
+func (t *dependencyTracker) track(sbr types.NamespacedName, refs []binding.ObjectReference) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.removeLocked(sbr)
+	for _, ref := range refs {
+		if _, ok := t.dependents[ref]; !ok {
+			t.dependents[ref] = make(namespacedNameSet)
+		}
+		t.dependents[ref].add(sbr)
+	}
+	if len(refs) > 0 {
+		t.dependencies[sbr] = refs
+	}
+}
+
+// forget removes all objects the given Service Binding depends on.
+func (t *dependencyTracker) forget(sbr types.NamespacedName) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.removeLocked(sbr)
+}
+
+// removeLocked removes the given Service Binding from the tracker; the caller must hold the lock.
+func (t *dependencyTracker) removeLocked(sbr types.NamespacedName) {
+	for _, ref := range t.dependencies[sbr] {
+		if dependents, ok := t.dependents[ref]; ok {
+			delete(dependents, sbr)
+			if len(dependents) == 0 {
+				delete(t.dependents, ref)
+			}
+		}
+	}
+	delete(t.dependencies, sbr)
+}
+
+// dependentsOf returns the Service Bindings depending on the object with the given GVK, namespace
+// and name.
+func (t *dependencyTracker) dependentsOf(
+	gvk schema.GroupVersionKind,
+	ns string,
+	name string,
+) []types.NamespacedName {
+	if t == nil {
+		return nil
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	ref := binding.ObjectReference{GroupVersionKind: gvk, Namespace: ns, Name: name}
+	sbrs := make([]types.NamespacedName, 0, len(t.dependents[ref]))
+	for sbr := range t.dependents[ref] {
+		sbrs = append(sbrs, sbr)
+	}
+	return sbrs
+}
+
+// dependencyRecorder is a dynamic.Interface recording the objects read through Get calls, found or
+// not, as dependencies of the Service Binding being collected; objects not found yet are recorded
+// as well, so their creation triggers a new reconciliation.
+type dependencyRecorder struct {
+	dynamic.Interface
+	restMapper meta.RESTMapper
+	mu         sync.Mutex
+	refs       []binding.ObjectReference
+	recorded   map[binding.ObjectReference]bool
+}
+
+var _ dynamic.Interface = (*dependencyRecorder)(nil)
+
+// newDependencyRecorder returns a dependencyRecorder reading objects through client.
+func newDependencyRecorder(client dynamic.Interface, restMapper meta.RESTMapper) *dependencyRecorder {
+	return &dependencyRecorder{
+		Interface:  client,
+		restMapper: restMapper,
+		recorded:   make(map[binding.ObjectReference]bool),
+	}
+}
+
+// references returns the objects read so far, in reading order.
+func (r *dependencyRecorder) references() []binding.ObjectReference {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]binding.ObjectReference(nil), r.refs...)
+}
+
+// record records the object read with the given GVR, namespace and name; reads of resources unknown
+// to the RESTMapper aren't recorded, since those can't be watched.
+func (r *dependencyRecorder) record(gvr schema.GroupVersionResource, ns string, name string) {
+	gvk, err := r.restMapper.KindFor(gvr)
+	if err != nil {
+		return
+	}
+	ref := binding.ObjectReference{GroupVersionKind: gvk, Namespace: ns, Name: name}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.recorded[ref] {
+		r.recorded[ref] = true
+		r.refs = append(r.refs, ref)
+	}
+}
+
+func (r *dependencyRecorder) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	resourceClient := r.Interface.Resource(gvr)
+	return &recordingNamespaceableResourceClient{
+		recordingResourceClient: &recordingResourceClient{
+			ResourceInterface: resourceClient,
+			recorder:          r,
+			gvr:               gvr,
+		},
+		resourceClient: resourceClient,
+	}
+}
+
+// recordingNamespaceableResourceClient records the objects read, cluster-wide or in a namespace.
+type recordingNamespaceableResourceClient struct {
+	*recordingResourceClient
+	resourceClient dynamic.NamespaceableResourceInterface
+}
+
+func (c *recordingNamespaceableResourceClient) Namespace(ns string) dynamic.ResourceInterface {
+	return &recordingResourceClient{
+		ResourceInterface: c.resourceClient.Namespace(ns),
+		recorder:          c.recorder,
+		gvr:               c.gvr,
+		namespace:         ns,
+	}
+}
+
+// recordingResourceClient records the objects read, optionally in a namespace.
+type recordingResourceClient struct {
+	dynamic.ResourceInterface
+	recorder  *dependencyRecorder
+	gvr       schema.GroupVersionResource
+	namespace string
+}
+
+func (c *recordingResourceClient) Get(
+	name string,
+	options metav1.GetOptions,
+	subresources ...string,
+) (*unstructured.Unstructured, error) {
+	u, err := c.ResourceInterface.Get(name, options, subresources...)
+	if len(subresources) == 0 && (err == nil || k8serrors.IsNotFound(err)) {
+		c.recorder.record(c.gvr, c.namespace, name)
+	}
+	return u, err
+}
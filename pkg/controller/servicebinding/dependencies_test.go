@@ -0,0 +1,88 @@
+package servicebinding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/controller/servicebinding/binding"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+func TestDependencyTracker(t *testing.T) {
+	serviceGVK := corev1.SchemeGroupVersion.WithKind("Service")
+	first := types.NamespacedName{Namespace: "ns", Name: "first"}
+	second := types.NamespacedName{Namespace: "ns", Name: "second"}
+	ref := func(name string) binding.ObjectReference {
+		return binding.ObjectReference{GroupVersionKind: serviceGVK, Namespace: "ns", Name: name}
+	}
+
+	t.Run("tracks dependents of an object", func(t *testing.T) {
+		tracker := newDependencyTracker()
+		tracker.track(first, []binding.ObjectReference{ref("db")})
+		tracker.track(second, []binding.ObjectReference{ref("db"), ref("cache")})
+
+		require.ElementsMatch(t, []types.NamespacedName{first, second}, tracker.dependentsOf(serviceGVK, "ns", "db"))
+		require.ElementsMatch(t, []types.NamespacedName{second}, tracker.dependentsOf(serviceGVK, "ns", "cache"))
+		require.Empty(t, tracker.dependentsOf(serviceGVK, "other-ns", "db"))
+	})
+
+	t.Run("replaces and forgets dependencies", func(t *testing.T) {
+		tracker := newDependencyTracker()
+		tracker.track(first, []binding.ObjectReference{ref("db")})
+		tracker.track(first, []binding.ObjectReference{ref("cache")})
+		require.Empty(t, tracker.dependentsOf(serviceGVK, "ns", "db"))
+		require.ElementsMatch(t, []types.NamespacedName{first}, tracker.dependentsOf(serviceGVK, "ns", "cache"))
+
+		tracker.forget(first)
+		require.Empty(t, tracker.dependentsOf(serviceGVK, "ns", "cache"))
+		require.Empty(t, tracker.dependencies)
+		require.Empty(t, tracker.dependents)
+	})
+
+	t.Run("nil tracker ignores all operations", func(t *testing.T) {
+		var tracker *dependencyTracker
+		tracker.track(first, []binding.ObjectReference{ref("db")})
+		tracker.forget(first)
+		require.Nil(t, tracker.dependentsOf(serviceGVK, "ns", "db"))
+	})
+}
+
+func TestDependencyRecorder(t *testing.T) {
+	ns := "recorder"
+	f := mocks.NewFake(t, ns)
+	f.AddMockedUnstructuredSecret("db-credentials")
+	f.AddMockedUnstructuredDatabaseCRD()
+	restMapper := buildCachedClientTestRESTMapper()
+	recorder := newDependencyRecorder(f.FakeDynClient(), restMapper)
+
+	secrets := recorder.Resource(corev1.SchemeGroupVersion.WithResource("secrets")).Namespace(ns)
+	_, err := secrets.Get("db-credentials", metav1.GetOptions{})
+	require.NoError(t, err)
+	// objects not found yet are recorded, and objects read twice recorded once
+	_, err = secrets.Get("db-tls", metav1.GetOptions{})
+	require.True(t, k8serrors.IsNotFound(err))
+	_, err = secrets.Get("db-credentials", metav1.GetOptions{})
+	require.NoError(t, err)
+	// cluster scoped objects are recorded without namespace
+	_, err = recorder.Resource(crdV1beta1GVR).Get("databases."+mocks.CRDName, metav1.GetOptions{})
+	require.NoError(t, err)
+	// resources unknown to the RESTMapper can't be watched, and aren't recorded
+	unknownGVR := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "unknowns"}
+	_, err = recorder.Resource(unknownGVR).Namespace(ns).Get("unknown", metav1.GetOptions{})
+	require.Error(t, err)
+	// lists aren't recorded
+	_, err = secrets.List(metav1.ListOptions{})
+	require.NoError(t, err)
+
+	require.Equal(t, []binding.ObjectReference{
+		{GroupVersionKind: secretGVK, Namespace: ns, Name: "db-credentials"},
+		{GroupVersionKind: secretGVK, Namespace: ns, Name: "db-tls"},
+		{GroupVersionKind: crdV1beta1GVR.GroupVersion().WithKind("CustomResourceDefinition"), Name: "databases." + mocks.CRDName},
+	}, recorder.references())
+}
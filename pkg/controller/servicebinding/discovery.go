@@ -0,0 +1,263 @@
+package servicebinding
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// resettableRESTMapper is a RESTMapper whose discovered resources can be refreshed.
+type resettableRESTMapper interface {
+	meta.RESTMapper
+	Reset()
+}
+
+// NewRESTMapper returns a RESTMapper discovering the API server resources on first use, and keeping
+// them until reset; the controller resets it whenever CRDs are installed, removed or established. It's meant to
+// be the manager's MapperProvider.
+func NewRESTMapper(cfg *rest.Config) (meta.RESTMapper, error) {
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc)), nil
+}
+
+// pendingKindTracker keeps track of the Service Bindings referring to kinds or resources the cluster
+// doesn't serve yet, so they can be reconciled again once the CRD declaring them is installed. A nil
+// pendingKindTracker ignores all operations.
+type pendingKindTracker struct {
+	mu        sync.Mutex
+	kinds     map[schema.GroupKind]namespacedNameSet
+	resources map[schema.GroupResource]namespacedNameSet
+}
+
+// newPendingKindTracker returns an empty pendingKindTracker.
+func newPendingKindTracker() *pendingKindTracker {
+	return &pendingKindTracker{
+		kinds:     make(map[schema.GroupKind]namespacedNameSet),
+		resources: make(map[schema.GroupResource]namespacedNameSet),
+	}
+}
+
+// waitFor records the given Service Binding as waiting for the kind or resource err reports as
+// missing; it returns false when err isn't a no match error.
+func (t *pendingKindTracker) waitFor(sbr types.NamespacedName, err error) bool {
+	var kindErr *meta.NoKindMatchError
+	var resourceErr *meta.NoResourceMatchError
+	switch {
+	case errors.As(err, &kindErr):
+		t.waitForKind(sbr, kindErr.GroupKind)
+	case errors.As(err, &resourceErr):
+		t.waitForResource(sbr, resourceErr.PartialResource.GroupResource())
+	default:
+		return false
+	}
+	return true
+}
+
+// waitForKind records the given Service Binding as waiting for the given kind.
+func (t *pendingKindTracker) waitForKind(sbr types.NamespacedName, gk schema.GroupKind) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.kinds[gk]; !ok {
+		t.kinds[gk] = make(namespacedNameSet)
+	}
+	t.kinds[gk].add(sbr)
+}
+
+// waitForResource records the given Service Binding as waiting for the given resource.
+func (t *pendingKindTracker) waitForResource(sbr types.NamespacedName, gr schema.GroupResource) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.resources[gr]; !ok {
+		t.resources[gr] = make(namespacedNameSet)
+	}
+	t.resources[gr].add(sbr)
+}
+
+// forget removes the given Service Binding from the tracker.
+func (t *pendingKindTracker) forget(sbr types.NamespacedName) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for gk, sbrs := range t.kinds {
+		delete(sbrs, sbr)
+		if len(sbrs) == 0 {
+			delete(t.kinds, gk)
+		}
+	}
+	for gr, sbrs := range t.resources {
+		delete(sbrs, sbr)
+		if len(sbrs) == 0 {
+			delete(t.resources, gr)
+		}
+	}
+}
+
+// installed returns, and stops tracking, the Service Bindings waiting for the given kind or
+// resource.
+func (t *pendingKindTracker) installed(gk schema.GroupKind, gr schema.GroupResource) namespacedNameSet {
+	sbrs := make(namespacedNameSet)
+	if t == nil {
+		return sbrs
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for sbr := range t.kinds[gk] {
+		sbrs.add(sbr)
+	}
+	for sbr := range t.resources[gr] {
+		sbrs.add(sbr)
+	}
+	delete(t.kinds, gk)
+	delete(t.resources, gr)
+	return sbrs
+}
+
+// restMapperResetInterval is the minimum interval between RESTMapper resets; resets requested
+// meanwhile, such as while an operator installs its CRDs, are coalesced.
+const restMapperResetInterval = 5 * time.Second
+
+// restMapperResetter resets a RESTMapper at most once per interval.
+type restMapperResetter struct {
+	restMapper meta.RESTMapper
+	interval   time.Duration
+
+	mu        sync.Mutex
+	last      time.Time
+	scheduled bool
+	// after are the functions to call once the pending reset is done.
+	after []func()
+}
+
+// newRESTMapperResetter returns a restMapperResetter for the given RESTMapper, which is never reset
+// when it isn't resettable.
+func newRESTMapperResetter(restMapper meta.RESTMapper, interval time.Duration) *restMapperResetter {
+	return &restMapperResetter{restMapper: restMapper, interval: interval}
+}
+
+// reset resets the RESTMapper, right away unless it has been reset less than an interval ago, and
+// calls then, when not nil, once it is done.
+func (r *restMapperResetter) reset(then func()) {
+	r.mu.Lock()
+	if then != nil {
+		r.after = append(r.after, then)
+	}
+	if r.scheduled {
+		r.mu.Unlock()
+		return
+	}
+	wait := r.interval - time.Since(r.last)
+	if wait > 0 {
+		r.scheduled = true
+		r.mu.Unlock()
+		time.AfterFunc(wait, r.flush)
+		return
+	}
+	r.mu.Unlock()
+	r.flush()
+}
+
+// flush resets the RESTMapper and calls the functions waiting for it.
+func (r *restMapperResetter) flush() {
+	r.mu.Lock()
+	r.scheduled = false
+	r.last = time.Now()
+	after := r.after
+	r.after = nil
+	r.mu.Unlock()
+
+	if rm, ok := r.restMapper.(resettableRESTMapper); ok {
+		rm.Reset()
+	}
+	for _, f := range after {
+		f()
+	}
+}
+
+// crdEventHandler refreshes the RESTMapper when CRDs are installed, removed or change whether they
+// are established, and enqueues the Service Bindings waiting for the kind established CRDs declare.
+// Other CRD changes, such as status updates, are ignored.
+type crdEventHandler struct {
+	resetter *restMapperResetter
+	pending  *pendingKindTracker
+}
+
+var _ handler.EventHandler = (*crdEventHandler)(nil)
+
+func (h *crdEventHandler) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.changed(evt.Object, q)
+}
+
+func (h *crdEventHandler) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	if isEstablished(evt.ObjectOld) != isEstablished(evt.ObjectNew) {
+		h.changed(evt.ObjectNew, q)
+	}
+}
+
+func (h *crdEventHandler) Delete(event.DeleteEvent, workqueue.RateLimitingInterface) {
+	h.resetter.reset(nil)
+}
+
+func (h *crdEventHandler) Generic(event.GenericEvent, workqueue.RateLimitingInterface) {}
+
+// changed resets the RESTMapper, and enqueues the Service Bindings waiting for the CRD's kind once
+// the CRD is established and the RESTMapper reset.
+func (h *crdEventHandler) changed(obj runtime.Object, q workqueue.RateLimitingInterface) {
+	if !isEstablished(obj) {
+		h.resetter.reset(nil)
+		return
+	}
+	u := obj.(*unstructured.Unstructured)
+	group, _, _ := unstructured.NestedString(u.Object, "spec", "group")
+	kind, _, _ := unstructured.NestedString(u.Object, "spec", "names", "kind")
+	plural, _, _ := unstructured.NestedString(u.Object, "spec", "names", "plural")
+	h.resetter.reset(func() {
+		sbrs := h.pending.installed(
+			schema.GroupKind{Group: group, Kind: kind},
+			schema.GroupResource{Group: group, Resource: plural},
+		)
+		for _, r := range convertToRequests(sbrs) {
+			q.Add(r)
+		}
+	})
+}
+
+// isEstablished returns whether the given object is an established CRD.
+func isEstablished(obj runtime.Object) bool {
+	u, ok := obj.(*unstructured.Unstructured)
+	return ok && isCRDEstablished(u)
+}
+
+// isCRDEstablished returns whether the CRD's resource is served.
+func isCRDEstablished(crd *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	for _, c := range conditions {
+		if m, ok := c.(map[string]interface{}); ok && m["type"] == "Established" {
+			return m["status"] == "True"
+		}
+	}
+	return false
+}
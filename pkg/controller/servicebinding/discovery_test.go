@@ -0,0 +1,139 @@
+package servicebinding
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/testutils"
+)
+
+var (
+	databaseGK = schema.GroupKind{Group: "postgresql.baiju.dev", Kind: "Database"}
+	databaseGR = schema.GroupResource{Group: "postgresql.baiju.dev", Resource: "databases"}
+)
+
+func TestPendingKindTracker(t *testing.T) {
+	first := types.NamespacedName{Namespace: "ns", Name: "first"}
+	second := types.NamespacedName{Namespace: "ns", Name: "second"}
+
+	t.Run("waits for missing kinds and resources", func(t *testing.T) {
+		tracker := newPendingKindTracker()
+		require.True(t, tracker.waitFor(first, &meta.NoKindMatchError{GroupKind: databaseGK}))
+		require.True(t, tracker.waitFor(second, &meta.NoResourceMatchError{
+			PartialResource: databaseGR.WithVersion("v1alpha1"),
+		}))
+		require.False(t, tracker.waitFor(first, errors.New("unexpected")))
+
+		require.Equal(t, namespacedNameSet{first: true, second: true}, tracker.installed(databaseGK, databaseGR))
+		// Service Bindings are only returned once
+		require.Empty(t, tracker.installed(databaseGK, databaseGR))
+	})
+
+	t.Run("forgets Service Bindings", func(t *testing.T) {
+		tracker := newPendingKindTracker()
+		tracker.waitForKind(first, databaseGK)
+		tracker.waitForResource(first, databaseGR)
+		tracker.waitForKind(second, databaseGK)
+		tracker.forget(first)
+		require.Equal(t, namespacedNameSet{second: true}, tracker.installed(databaseGK, databaseGR))
+		require.Empty(t, tracker.kinds)
+		require.Empty(t, tracker.resources)
+	})
+
+	t.Run("nil tracker", func(t *testing.T) {
+		var tracker *pendingKindTracker
+		tracker.waitForKind(first, databaseGK)
+		tracker.forget(first)
+		require.Empty(t, tracker.installed(databaseGK, databaseGR))
+	})
+}
+
+// resetCountingRESTMapper is a resettable RESTMapper counting how often it's been reset.
+type resetCountingRESTMapper struct {
+	meta.RESTMapper
+	resets int32
+}
+
+func (m *resetCountingRESTMapper) Reset() {
+	atomic.AddInt32(&m.resets, 1)
+}
+
+func (m *resetCountingRESTMapper) count() int {
+	return int(atomic.LoadInt32(&m.resets))
+}
+
+func TestRESTMapperResetter(t *testing.T) {
+	restMapper := &resetCountingRESTMapper{RESTMapper: testutils.BuildTestRESTMapper()}
+	resetter := newRESTMapperResetter(restMapper, 50*time.Millisecond)
+
+	done := make(chan struct{}, 3)
+	for i := 0; i < 3; i++ {
+		resetter.reset(func() { done <- struct{}{} })
+	}
+	require.Equal(t, 1, restMapper.count(), "the first reset is done right away")
+	require.Len(t, done, 1)
+
+	// resets requested meanwhile are coalesced
+	require.Eventually(t, func() bool { return len(done) == 3 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, 2, restMapper.count())
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, 2, restMapper.count())
+}
+
+func TestCRDEventHandler(t *testing.T) {
+	sbr := types.NamespacedName{Namespace: "ns", Name: "binding"}
+	restMapper := &resetCountingRESTMapper{RESTMapper: testutils.BuildTestRESTMapper()}
+	pending := newPendingKindTracker()
+	pending.waitForKind(sbr, databaseGK)
+	h := &crdEventHandler{resetter: newRESTMapperResetter(restMapper, 0), pending: pending}
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"group": databaseGK.Group,
+			"names": map[string]interface{}{"kind": databaseGK.Kind, "plural": databaseGR.Resource},
+		},
+	}}
+	crd.SetGroupVersionKind(crdGVR.GroupVersion().WithKind("CustomResourceDefinition"))
+	crd.SetName(databaseGR.String())
+
+	// CRDs not established yet only refresh the RESTMapper
+	h.Create(event.CreateEvent{Meta: crd, Object: crd}, q)
+	require.Equal(t, 1, restMapper.count())
+	require.Zero(t, q.Len())
+
+	// other changes don't refresh the RESTMapper
+	updated := crd.DeepCopy()
+	updated.SetLabels(map[string]string{"app": "db"})
+	h.Update(event.UpdateEvent{MetaOld: crd, ObjectOld: crd, MetaNew: updated, ObjectNew: updated}, q)
+	require.Equal(t, 1, restMapper.count())
+
+	established := updated.DeepCopy()
+	require.NoError(t, unstructured.SetNestedSlice(established.Object, []interface{}{
+		map[string]interface{}{"type": "NamesAccepted", "status": "True"},
+		map[string]interface{}{"type": "Established", "status": "True"},
+	}, "status", "conditions"))
+	h.Update(event.UpdateEvent{MetaOld: updated, ObjectOld: updated, MetaNew: established, ObjectNew: established}, q)
+	require.Equal(t, 2, restMapper.count())
+	require.Equal(t, 1, q.Len())
+	item, _ := q.Get()
+	require.Equal(t, reconcile.Request{NamespacedName: sbr}, item)
+
+	h.Update(event.UpdateEvent{MetaOld: established, ObjectOld: established, MetaNew: established, ObjectNew: established}, q)
+	require.Equal(t, 2, restMapper.count())
+
+	h.Delete(event.DeleteEvent{Meta: established, Object: established}, q)
+	require.Equal(t, 3, restMapper.count())
+}
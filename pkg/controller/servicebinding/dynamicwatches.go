@@ -0,0 +1,131 @@
+package servicebinding
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+const (
+	// serviceBindingOwner identifies watches started on behalf of a ServiceBinding.
+	serviceBindingOwner = "ServiceBinding"
+	// csvOwner identifies watches started on behalf of a ClusterServiceVersion.
+	csvOwner = "ClusterServiceVersion"
+)
+
+// watchOwner is an object on whose behalf kinds are watched.
+type watchOwner struct {
+	kind string
+	types.NamespacedName
+}
+
+// startWatchFunc starts watching the given kind, returning the channel stopping the watch once
+// closed.
+type startWatchFunc func(gvk schema.GroupVersionKind) (chan struct{}, error)
+
+// dynamicWatch is a watch shared by its owners, and stopped once none of them needs it anymore.
+type dynamicWatch struct {
+	owners map[watchOwner]bool
+	stop   chan struct{}
+}
+
+// dynamicWatches reference-counts the kinds watched on behalf of ServiceBindings and
+// ClusterServiceVersions, starting a watch when a kind gets its first owner and stopping it when
+// the last owner releases it.
+type dynamicWatches struct {
+	mu sync.Mutex
+	// owned maps an owner to the kinds watched on its behalf.
+	owned map[watchOwner]map[schema.GroupVersionKind]bool
+	// watches maps a watched kind to its watch.
+	watches map[schema.GroupVersionKind]*dynamicWatch
+	start   startWatchFunc
+}
+
+// newDynamicWatches returns an empty dynamicWatches starting watches with start.
+func newDynamicWatches(start startWatchFunc) *dynamicWatches {
+	return &dynamicWatches{
+		owned:   make(map[watchOwner]map[schema.GroupVersionKind]bool),
+		watches: make(map[schema.GroupVersionKind]*dynamicWatch),
+		start:   start,
+	}
+}
+
+// set replaces the kinds watched on behalf of owner. Kinds which couldn't be watched aren't
+// recorded for owner, so they are tried again on the next call.
+func (w *dynamicWatches) set(owner watchOwner, gvks []schema.GroupVersionKind) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var errs []error
+	wanted := make(map[schema.GroupVersionKind]bool, len(gvks))
+	for _, gvk := range gvks {
+		if wanted[gvk] {
+			continue
+		}
+		watch, ok := w.watches[gvk]
+		if !ok {
+			stop, err := w.start(gvk)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			watch = &dynamicWatch{owners: make(map[watchOwner]bool), stop: stop}
+			w.watches[gvk] = watch
+			activeWatchesGauge.WithLabelValues(dynamicWatchType).Inc()
+		}
+		wanted[gvk] = true
+		watch.owners[owner] = true
+		observeWatchOwners(gvk, len(watch.owners))
+	}
+
+	for gvk := range w.owned[owner] {
+		if !wanted[gvk] {
+			w.releaseLocked(owner, gvk)
+		}
+	}
+	if len(wanted) > 0 {
+		w.owned[owner] = wanted
+	} else {
+		delete(w.owned, owner)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// release stops watching kinds on behalf of owner.
+func (w *dynamicWatches) release(owner watchOwner) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for gvk := range w.owned[owner] {
+		w.releaseLocked(owner, gvk)
+	}
+	delete(w.owned, owner)
+}
+
+// releaseLocked removes owner from the watch on gvk, stopping the watch when owner was its last
+// owner; the caller must hold the lock.
+func (w *dynamicWatches) releaseLocked(owner watchOwner, gvk schema.GroupVersionKind) {
+	watch, ok := w.watches[gvk]
+	if !ok {
+		return
+	}
+	delete(watch.owners, owner)
+	observeWatchOwners(gvk, len(watch.owners))
+	if len(watch.owners) > 0 {
+		return
+	}
+	close(watch.stop)
+	delete(w.watches, gvk)
+	activeWatchesGauge.WithLabelValues(dynamicWatchType).Dec()
+}
+
+// owners returns the number of owners of the watch on gvk.
+func (w *dynamicWatches) owners(gvk schema.GroupVersionKind) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if watch, ok := w.watches[gvk]; ok {
+		return len(watch.owners)
+	}
+	return 0
+}
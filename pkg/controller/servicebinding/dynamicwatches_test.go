@@ -0,0 +1,73 @@
+package servicebinding
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestDynamicWatches(t *testing.T) {
+	fooGVK := schema.GroupVersionKind{Group: "watches.test", Version: "v1", Kind: "Foo"}
+	barGVK := schema.GroupVersionKind{Group: "watches.test", Version: "v1", Kind: "Bar"}
+	brokenGVK := schema.GroupVersionKind{Group: "watches.test", Version: "v1", Kind: "Broken"}
+	first := watchOwner{kind: serviceBindingOwner, NamespacedName: types.NamespacedName{Namespace: "ns", Name: "first"}}
+	second := watchOwner{kind: csvOwner, NamespacedName: types.NamespacedName{Namespace: "ns", Name: "second"}}
+
+	stops := make(map[schema.GroupVersionKind]chan struct{})
+	w := newDynamicWatches(func(gvk schema.GroupVersionKind) (chan struct{}, error) {
+		if gvk == brokenGVK {
+			return nil, errors.New("unavailable")
+		}
+		stops[gvk] = make(chan struct{})
+		return stops[gvk], nil
+	})
+	stopped := func(gvk schema.GroupVersionKind) bool {
+		select {
+		case <-stops[gvk]:
+			return true
+		default:
+			return false
+		}
+	}
+	ownersGauge := func(gvk schema.GroupVersionKind) float64 {
+		return testutil.ToFloat64(watchOwnersGauge.WithLabelValues(gvk.Group, gvk.Version, gvk.Kind))
+	}
+	activeBefore := testutil.ToFloat64(activeWatchesGauge.WithLabelValues(dynamicWatchType))
+	active := func() float64 {
+		return testutil.ToFloat64(activeWatchesGauge.WithLabelValues(dynamicWatchType)) - activeBefore
+	}
+
+	require.NoError(t, w.set(first, []schema.GroupVersionKind{fooGVK, fooGVK, barGVK}))
+	require.NoError(t, w.set(second, []schema.GroupVersionKind{fooGVK}))
+	require.Len(t, stops, 2)
+	require.Equal(t, 2, w.owners(fooGVK))
+	require.Equal(t, 1, w.owners(barGVK))
+	require.Equal(t, float64(2), ownersGauge(fooGVK))
+	require.Equal(t, float64(2), active())
+
+	// kinds which can't be watched aren't recorded, and are tried again on the next call
+	require.Error(t, w.set(first, []schema.GroupVersionKind{fooGVK, brokenGVK}))
+	require.Equal(t, 0, w.owners(brokenGVK))
+	require.True(t, stopped(barGVK), "watches without owners are stopped")
+	require.Equal(t, float64(0), ownersGauge(barGVK))
+	require.Equal(t, float64(1), active())
+
+	w.release(first)
+	require.False(t, stopped(fooGVK))
+	require.Equal(t, 1, w.owners(fooGVK))
+
+	require.NoError(t, w.set(second, nil))
+	require.True(t, stopped(fooGVK))
+	require.Empty(t, w.watches)
+	require.Empty(t, w.owned)
+	require.Equal(t, float64(0), active())
+
+	// released kinds are watched again when needed
+	require.NoError(t, w.set(first, []schema.GroupVersionKind{fooGVK}))
+	require.False(t, stopped(fooGVK))
+	require.Equal(t, float64(1), active())
+}
@@ -0,0 +1,35 @@
+package servicebinding
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// SecretCreatedReason is used when the intermediate secret is created.
+	SecretCreatedReason = "SecretCreated"
+	// SecretUpdatedReason is used when the intermediate secret data changes.
+	SecretUpdatedReason = "SecretUpdated"
+	// ApplicationBoundReason is used when an application workload is bound.
+	ApplicationBoundReason = "ApplicationBound"
+	// ApplicationUnboundReason is used when an application workload is unbound.
+	ApplicationUnboundReason = "ApplicationUnbound"
+	// AnnotationErrorReason is used when a binding annotation declared by a service can't be
+	// processed.
+	AnnotationErrorReason = "AnnotationError"
+)
+
+// recordEvent emits an event on the given object; events are ignored when recorder is nil.
+func recordEvent(
+	recorder record.EventRecorder,
+	obj runtime.Object,
+	eventType string,
+	reason string,
+	messageFmt string,
+	args ...interface{},
+) {
+	if recorder == nil {
+		return
+	}
+	recorder.Eventf(obj, eventType, reason, messageFmt, args...)
+}
@@ -0,0 +1,239 @@
+package servicebinding
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// fairQueue is a workqueue.RateLimitingInterface handing out its items round-robin across
+// namespaces: each namespace having items waiting gets one in turn, however many it has, so a
+// namespace with many ServiceBindings, or many failing ones, can't delay the others. Like the
+// client-go work queue, an item is handed out to one worker at a time, and an item added while it's
+// processed is handed out again once done.
+type fairQueue struct {
+	cond *sync.Cond
+	// queues holds the items waiting to be handed out, per namespace.
+	queues map[string][]interface{}
+	// order holds the namespaces having items waiting, in the order they are served.
+	order []string
+	// dirty holds the items to be handed out, waiting or processed.
+	dirty map[interface{}]bool
+	// processing holds the items handed out and not done yet.
+	processing map[interface{}]bool
+	// waiting holds the items added with a delay, until their earliest deadline.
+	waiting      map[interface{}]*waitingItem
+	shuttingDown bool
+	rateLimiter  workqueue.RateLimiter
+	metrics      *queueMetrics
+}
+
+var _ workqueue.RateLimitingInterface = (*fairQueue)(nil)
+
+// waitingItem is an item added once its deadline is reached.
+type waitingItem struct {
+	readyAt time.Time
+	timer   *time.Timer
+}
+
+// newFairQueue returns an empty fairQueue delaying retries with the given rate limiter. Like client-go
+// queues, its metrics are exposed under the given name, unless empty.
+func newFairQueue(name string, rateLimiter workqueue.RateLimiter) *fairQueue {
+	q := &fairQueue{
+		cond:        sync.NewCond(&sync.Mutex{}),
+		queues:      make(map[string][]interface{}),
+		dirty:       make(map[interface{}]bool),
+		processing:  make(map[interface{}]bool),
+		waiting:     make(map[interface{}]*waitingItem),
+		rateLimiter: rateLimiter,
+		metrics:     newQueueMetrics(name, workqueueMetricsProvider{}),
+	}
+	if q.metrics != nil {
+		go q.updateUnfinishedWorkLoop()
+	}
+	return q
+}
+
+// updateUnfinishedWorkLoop updates the metrics of the items being processed until the queue is shut
+// down.
+func (q *fairQueue) updateUnfinishedWorkLoop() {
+	t := time.NewTicker(queueMetricsUpdatePeriod)
+	defer t.Stop()
+	for range t.C {
+		q.cond.L.Lock()
+		if q.shuttingDown {
+			q.cond.L.Unlock()
+			return
+		}
+		q.metrics.updateUnfinishedWork()
+		q.cond.L.Unlock()
+	}
+}
+
+// namespaceOf returns the namespace the given item is served in; items other than requests share
+// the empty namespace.
+func namespaceOf(item interface{}) string {
+	if req, ok := item.(reconcile.Request); ok {
+		return req.Namespace
+	}
+	return ""
+}
+
+// pushLocked appends the given item to its namespace's queue; the caller must hold the lock.
+func (q *fairQueue) pushLocked(item interface{}) {
+	ns := namespaceOf(item)
+	if len(q.queues[ns]) == 0 {
+		q.order = append(q.order, ns)
+	}
+	q.queues[ns] = append(q.queues[ns], item)
+	q.cond.Signal()
+}
+
+// Add marks the given item as needing processing.
+func (q *fairQueue) Add(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.addLocked(item)
+}
+
+// addLocked marks the given item as needing processing; the caller must hold the lock.
+func (q *fairQueue) addLocked(item interface{}) {
+	if q.shuttingDown || q.dirty[item] {
+		return
+	}
+	q.metrics.add(item)
+	q.dirty[item] = true
+	if q.processing[item] {
+		return
+	}
+	q.pushLocked(item)
+}
+
+// Len returns the number of items waiting to be handed out.
+func (q *fairQueue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	n := 0
+	for _, items := range q.queues {
+		n += len(items)
+	}
+	return n
+}
+
+// Get blocks until an item can be handed out, and returns the first item of the next namespace in
+// turn; shutdown is true once the queue is shut down.
+func (q *fairQueue) Get() (item interface{}, shutdown bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	for len(q.order) == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	if len(q.order) == 0 {
+		return nil, true
+	}
+
+	ns := q.order[0]
+	q.order = q.order[1:]
+	item, rest := q.queues[ns][0], q.queues[ns][1:]
+	if len(rest) > 0 {
+		q.queues[ns] = rest
+		// the namespace takes its next turn after the others
+		q.order = append(q.order, ns)
+	} else {
+		delete(q.queues, ns)
+	}
+	q.metrics.get(item)
+	q.processing[item] = true
+	delete(q.dirty, item)
+	return item, false
+}
+
+// Done marks the given item as processed; it's queued again when it was added meanwhile.
+func (q *fairQueue) Done(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.metrics.done(item)
+	delete(q.processing, item)
+	if q.dirty[item] {
+		q.pushLocked(item)
+	}
+}
+
+// ShutDown makes Get return once the waiting items are handed out, and ignores the items added
+// from then on.
+func (q *fairQueue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.shuttingDown = true
+	for item, w := range q.waiting {
+		w.timer.Stop()
+		delete(q.waiting, item)
+	}
+	q.cond.Broadcast()
+}
+
+// ShuttingDown returns whether the queue is shut down.
+func (q *fairQueue) ShuttingDown() bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return q.shuttingDown
+}
+
+// AddAfter adds the given item once the given duration has elapsed. Like the client-go delaying
+// queue, an item waits once: it's added at the earliest of its deadlines.
+func (q *fairQueue) AddAfter(item interface{}, duration time.Duration) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if q.shuttingDown {
+		return
+	}
+	if duration <= 0 {
+		q.addLocked(item)
+		return
+	}
+
+	readyAt := time.Now().Add(duration)
+	if w, ok := q.waiting[item]; ok {
+		if !readyAt.Before(w.readyAt) {
+			return
+		}
+		w.timer.Stop()
+	}
+	w := &waitingItem{readyAt: readyAt}
+	w.timer = time.AfterFunc(duration, func() {
+		q.addWaiting(item, w)
+	})
+	q.waiting[item] = w
+}
+
+// addWaiting adds the given item once its deadline is reached, unless it has been rescheduled
+// meanwhile.
+func (q *fairQueue) addWaiting(item interface{}, w *waitingItem) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if q.waiting[item] != w {
+		return
+	}
+	delete(q.waiting, item)
+	q.addLocked(item)
+}
+
+// AddRateLimited adds the given item once the rate limiter allows it.
+func (q *fairQueue) AddRateLimited(item interface{}) {
+	q.cond.L.Lock()
+	q.metrics.retry()
+	q.cond.L.Unlock()
+	q.AddAfter(item, q.rateLimiter.When(item))
+}
+
+// Forget forgets the retries of the given item.
+func (q *fairQueue) Forget(item interface{}) {
+	q.rateLimiter.Forget(item)
+}
+
+// NumRequeues returns the number of retries of the given item.
+func (q *fairQueue) NumRequeues(item interface{}) int {
+	return q.rateLimiter.NumRequeues(item)
+}
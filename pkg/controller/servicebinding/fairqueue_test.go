@@ -0,0 +1,118 @@
+package servicebinding
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// request returns a request for the ServiceBinding with the given namespace and name.
+func request(ns, name string) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: name}}
+}
+
+// getAll returns the items handed out by the given queue until it's empty, marking them done.
+func getAll(q *fairQueue) []interface{} {
+	var items []interface{}
+	for q.Len() > 0 {
+		item, _ := q.Get()
+		items = append(items, item)
+		q.Done(item)
+	}
+	return items
+}
+
+func TestFairQueue(t *testing.T) {
+	t.Run("namespaces served in turn", func(t *testing.T) {
+		q := newFairQueue("", newBackoffRateLimiter())
+		for i := 0; i < 3; i++ {
+			q.Add(request("busy", fmt.Sprintf("sbr-%d", i)))
+		}
+		q.Add(request("quiet", "sbr"))
+		q.Add(request("other", "sbr"))
+
+		require.Equal(t, []interface{}{
+			request("busy", "sbr-0"),
+			request("quiet", "sbr"),
+			request("other", "sbr"),
+			request("busy", "sbr-1"),
+			request("busy", "sbr-2"),
+		}, getAll(q))
+	})
+
+	t.Run("items queued once", func(t *testing.T) {
+		q := newFairQueue("", newBackoffRateLimiter())
+		q.Add(request("ns", "sbr"))
+		q.Add(request("ns", "sbr"))
+		require.Equal(t, 1, q.Len())
+
+		item, _ := q.Get()
+		q.Add(request("ns", "sbr"))
+		require.Equal(t, 0, q.Len(), "items processed are queued again once done")
+		q.Done(item)
+		require.Equal(t, []interface{}{request("ns", "sbr")}, getAll(q))
+	})
+
+	t.Run("delayed items", func(t *testing.T) {
+		q := newFairQueue("", newBackoffRateLimiter())
+		q.AddAfter(request("ns", "sbr"), 10*time.Millisecond)
+		require.Equal(t, 0, q.Len())
+		item, shutdown := q.Get()
+		require.False(t, shutdown)
+		require.Equal(t, request("ns", "sbr"), item)
+	})
+
+	t.Run("delayed items added once", func(t *testing.T) {
+		for _, delays := range [][]time.Duration{
+			{10 * time.Millisecond, 50 * time.Millisecond},
+			{50 * time.Millisecond, 10 * time.Millisecond},
+		} {
+			q := newFairQueue("", newBackoffRateLimiter())
+			for _, delay := range delays {
+				q.AddAfter(request("ns", "sbr"), delay)
+			}
+			start := time.Now()
+			item, _ := q.Get()
+			require.Less(t, int64(time.Since(start)), int64(50*time.Millisecond), "the earliest deadline is kept")
+			q.Done(item)
+
+			time.Sleep(100 * time.Millisecond)
+			require.Equal(t, 0, q.Len(), "the item is delivered once")
+		}
+	})
+
+	t.Run("shut down", func(t *testing.T) {
+		q := newFairQueue("", newBackoffRateLimiter())
+		q.Add(request("ns", "sbr"))
+		q.ShutDown()
+		require.True(t, q.ShuttingDown())
+		q.Add(request("ns", "other"))
+
+		item, shutdown := q.Get()
+		require.False(t, shutdown, "waiting items are handed out")
+		require.Equal(t, request("ns", "sbr"), item)
+		_, shutdown = q.Get()
+		require.True(t, shutdown)
+	})
+	t.Run("metrics", func(t *testing.T) {
+		const name = "fairqueue-test"
+		q := newFairQueue(name, newBackoffRateLimiter())
+		defer q.ShutDown()
+		q.Add(request("ns", "sbr"))
+		q.Add(request("ns", "other"))
+		require.Equal(t, float64(2), testutil.ToFloat64(q.metrics.depth.(prometheus.Gauge)))
+
+		item, _ := q.Get()
+		q.Done(item)
+		q.AddRateLimited(item)
+		require.Equal(t, float64(1), testutil.ToFloat64(q.metrics.depth.(prometheus.Gauge)))
+		require.Equal(t, float64(2), testutil.ToFloat64(q.metrics.adds.(prometheus.Counter)))
+		require.Equal(t, float64(1), testutil.ToFloat64(q.metrics.retries.(prometheus.Counter)))
+	})
+}
@@ -0,0 +1,119 @@
+package servicebinding
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/log"
+)
+
+var healthLog = log.NewLog("health")
+
+// watchRetryPeriod is the delay between attempts to set up the watches which failed.
+const watchRetryPeriod = 30 * time.Second
+
+// watchStatus keeps track of the watches the operator can run without, for example the watch on
+// ClusterServiceVersions, and of the ones whose setup failed, so they're set up again and reported
+// by the readiness probe meanwhile. A nil watchStatus sets watches up without keeping track of
+// them.
+type watchStatus struct {
+	mu sync.Mutex
+	// failed maps the names of the watches whose setup failed to their setup function.
+	failed map[string]func() error
+	// errs maps the names of the watches whose setup failed to the last error.
+	errs map[string]error
+}
+
+// newWatchStatus returns a watchStatus without failed watches.
+func newWatchStatus() *watchStatus {
+	return &watchStatus{
+		failed: make(map[string]func() error),
+		errs:   make(map[string]error),
+	}
+}
+
+// setUp sets up the watch with the given name, recording it as failed when setUp fails.
+func (w *watchStatus) setUp(name string, setUp func() error) error {
+	err := setUp()
+	if w == nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err != nil {
+		w.failed[name] = setUp
+		w.errs[name] = err
+	} else {
+		delete(w.failed, name)
+		delete(w.errs, name)
+	}
+	return err
+}
+
+// retry sets up again the watches which failed.
+func (w *watchStatus) retry() {
+	w.mu.Lock()
+	failed := make(map[string]func() error, len(w.failed))
+	for name, setUp := range w.failed {
+		failed[name] = setUp
+	}
+	w.mu.Unlock()
+
+	for name, setUp := range failed {
+		if err := w.setUp(name, setUp); err != nil {
+			healthLog.Error(err, "on setting up watch again", "Watch", name)
+		} else {
+			healthLog.Info("Watch set up", "Watch", name)
+		}
+	}
+}
+
+// check is a healthz.Checker failing while watches failed to be set up.
+func (w *watchStatus) check(*http.Request) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.errs) == 0 {
+		return nil
+	}
+	failures := make([]string, 0, len(w.errs))
+	for name, err := range w.errs {
+		failures = append(failures, fmt.Sprintf("%s: %s", name, err))
+	}
+	sort.Strings(failures)
+	return fmt.Errorf("watches failed to be set up: %s", strings.Join(failures, "; "))
+}
+
+// errCachesNotSynced is returned by the readiness probe until the controller caches are synced.
+var errCachesNotSynced = errors.New("informer caches are not synced")
+
+// checkCachesSynced is a healthz.Checker failing until the informer caches of the controller are
+// synced and its workers started.
+func (s *sbrController) checkCachesSynced(*http.Request) error {
+	if c, ok := s.Controller.(*queueController); ok && !c.hasStarted() {
+		return errCachesNotSynced
+	}
+	return nil
+}
+
+// addHealthChecks registers the readiness checks of the controller, and sets up the failed watches
+// again until they succeed.
+func addHealthChecks(mgr manager.Manager, c *sbrController) error {
+	if err := mgr.AddReadyzCheck("informers", c.checkCachesSynced); err != nil {
+		return err
+	}
+	if err := mgr.AddReadyzCheck("watches", c.watches.check); err != nil {
+		return err
+	}
+	return mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		wait.Until(c.watches.retry, watchRetryPeriod, stop)
+		return nil
+	}))
+}
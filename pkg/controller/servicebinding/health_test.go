@@ -0,0 +1,54 @@
+package servicebinding
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchStatus(t *testing.T) {
+	status := newWatchStatus()
+	require.NoError(t, status.check(nil))
+
+	attempts := 0
+	setUp := func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("no such kind")
+		}
+		return nil
+	}
+	require.Error(t, status.setUp("ClusterServiceVersion", setUp))
+	require.NoError(t, status.setUp("CustomResourceDefinition", func() error { return nil }))
+
+	err := status.check(nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ClusterServiceVersion: no such kind")
+	require.NotContains(t, err.Error(), "CustomResourceDefinition")
+
+	status.retry()
+	require.Equal(t, 2, attempts)
+	require.Error(t, status.check(nil), "the watch failed again")
+
+	status.retry()
+	require.Equal(t, 3, attempts)
+	require.NoError(t, status.check(nil))
+
+	status.retry()
+	require.Equal(t, 3, attempts, "watches set up aren't set up again")
+}
+
+func TestWatchStatusNil(t *testing.T) {
+	var status *watchStatus
+	require.Error(t, status.setUp("ClusterServiceVersion", func() error { return errors.New("no such kind") }))
+}
+
+func TestCheckCachesSynced(t *testing.T) {
+	c := &queueController{}
+	controller := &sbrController{Controller: c}
+	require.Equal(t, errCachesNotSynced, controller.checkCachesSynced(nil))
+
+	c.synced = 1
+	require.NoError(t, controller.checkCachesSynced(nil))
+}
@@ -0,0 +1,175 @@
+package servicebinding
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/log"
+)
+
+var (
+	indexLog = log.NewLog("index")
+)
+
+const (
+	// serviceIndex indexes ServiceBindings by the GVK, namespace and name of each declared service.
+	serviceIndex = "services"
+	// applicationIndex indexes ServiceBindings by the GVR, namespace and name of the application,
+	// when the application is declared by name.
+	applicationIndex = "application"
+	// applicationSelectorIndex indexes ServiceBindings by the GVR and namespace of the application,
+	// when the application is declared by label selector.
+	applicationSelectorIndex = "applicationSelector"
+	// secretIndex indexes ServiceBindings by the namespace and name of the intermediate secret.
+	secretIndex = "secret"
+)
+
+// serviceIndexKey returns the serviceIndex key of the given service.
+func serviceIndexKey(gvk schema.GroupVersionKind, ns string, name string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", gvk.GroupVersion().String(), gvk.Kind, ns, name)
+}
+
+// applicationIndexKey returns the applicationIndex key of the given application.
+func applicationIndexKey(gvr schema.GroupVersionResource, ns string, name string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", gvr.GroupVersion().String(), gvr.Resource, ns, name)
+}
+
+// applicationSelectorIndexKey returns the applicationSelectorIndex key of the given application
+// resource and namespace.
+func applicationSelectorIndexKey(gvr schema.GroupVersionResource, ns string) string {
+	return fmt.Sprintf("%s/%s/%s", gvr.GroupVersion().String(), gvr.Resource, ns)
+}
+
+// secretIndexKey returns the secretIndex key of the given secret.
+func secretIndexKey(ns string, name string) string {
+	return ns + "/" + name
+}
+
+// toServiceBinding converts objects stored in the informer cache into ServiceBindings.
+func toServiceBinding(obj interface{}) (*v1alpha1.ServiceBinding, error) {
+	switch o := obj.(type) {
+	case *v1alpha1.ServiceBinding:
+		return o, nil
+	case *unstructured.Unstructured:
+		return convertToSBR(o.Object)
+	default:
+		return nil, fmt.Errorf("unexpected object of type %T", obj)
+	}
+}
+
+// serviceBindingIndexFunc adapts fn to a toolscache.IndexFunc.
+func serviceBindingIndexFunc(fn func(sbr *v1alpha1.ServiceBinding) []string) toolscache.IndexFunc {
+	return func(obj interface{}) ([]string, error) {
+		sbr, err := toServiceBinding(obj)
+		if err != nil {
+			return nil, err
+		}
+		return fn(sbr), nil
+	}
+}
+
+// serviceBindingIndexers returns the indexers used to look up ServiceBindings related to an object.
+func serviceBindingIndexers() toolscache.Indexers {
+	return toolscache.Indexers{
+		serviceIndex: serviceBindingIndexFunc(func(sbr *v1alpha1.ServiceBinding) []string {
+			keys := make([]string, 0, len(sbr.Spec.Services))
+			for _, svc := range sbr.Spec.Services {
+				ns := sbr.GetNamespace()
+				if svc.Namespace != nil && len(*svc.Namespace) > 0 {
+					ns = *svc.Namespace
+				}
+				gvk := schema.GroupVersionKind{Group: svc.Group, Version: svc.Version, Kind: svc.Kind}
+				keys = append(keys, serviceIndexKey(gvk, ns, svc.Name))
+			}
+			return keys
+		}),
+		applicationIndex: serviceBindingIndexFunc(func(sbr *v1alpha1.ServiceBinding) []string {
+			app := sbr.Spec.Application
+			if app == nil || len(app.Name) == 0 {
+				return nil
+			}
+			gvr := schema.GroupVersionResource{Group: app.Group, Version: app.Version, Resource: app.Resource}
+			return []string{applicationIndexKey(gvr, sbr.GetNamespace(), app.Name)}
+		}),
+		applicationSelectorIndex: serviceBindingIndexFunc(func(sbr *v1alpha1.ServiceBinding) []string {
+			app := sbr.Spec.Application
+			if app == nil || len(app.Name) > 0 {
+				return nil
+			}
+			gvr := schema.GroupVersionResource{Group: app.Group, Version: app.Version, Resource: app.Resource}
+			return []string{applicationSelectorIndexKey(gvr, sbr.GetNamespace())}
+		}),
+		secretIndex: serviceBindingIndexFunc(func(sbr *v1alpha1.ServiceBinding) []string {
+			if len(sbr.Status.Secret) == 0 {
+				return nil
+			}
+			return []string{secretIndexKey(sbr.GetNamespace(), sbr.Status.Secret)}
+		}),
+	}
+}
+
+// serviceBindingIndex looks up ServiceBindings in an index kept up to date through the informer's
+// events, so events are resolved without listing ServiceBindings from the API server.
+type serviceBindingIndex struct {
+	indexer toolscache.Indexer
+}
+
+// newServiceBindingIndex returns an index of the ServiceBindings held by the given informer, which
+// may be made of an informer per watched namespace.
+func newServiceBindingIndex(informer cache.Informer) *serviceBindingIndex {
+	index := &serviceBindingIndex{
+		indexer: toolscache.NewIndexer(toolscache.DeletionHandlingMetaNamespaceKeyFunc, serviceBindingIndexers()),
+	}
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: index.update,
+		UpdateFunc: func(_, obj interface{}) {
+			index.update(obj)
+		},
+		DeleteFunc: index.delete,
+	})
+	return index
+}
+
+// update adds or replaces the given ServiceBinding in the index.
+func (i *serviceBindingIndex) update(obj interface{}) {
+	if err := i.indexer.Update(obj); err != nil {
+		indexLog.Error(err, "on indexing ServiceBinding")
+	}
+}
+
+// delete removes the given ServiceBinding, or its tombstone, from the index.
+func (i *serviceBindingIndex) delete(obj interface{}) {
+	if err := i.indexer.Delete(obj); err != nil {
+		indexLog.Error(err, "on removing ServiceBinding from the index")
+	}
+}
+
+// list returns all ServiceBindings in the index.
+func (i *serviceBindingIndex) list() []interface{} {
+	return i.indexer.List()
+}
+
+// lookup returns the ServiceBindings which index contains the given key.
+func (i *serviceBindingIndex) lookup(index string, key string) ([]*v1alpha1.ServiceBinding, error) {
+	if i == nil {
+		return nil, nil
+	}
+	objs, err := i.indexer.ByIndex(index, key)
+	if err != nil {
+		return nil, err
+	}
+	sbrs := make([]*v1alpha1.ServiceBinding, 0, len(objs))
+	for _, obj := range objs {
+		sbr, err := toServiceBinding(obj)
+		if err != nil {
+			return nil, err
+		}
+		sbrs = append(sbrs, sbr)
+	}
+	return sbrs, nil
+}
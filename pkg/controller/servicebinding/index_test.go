@@ -0,0 +1,108 @@
+package servicebinding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+)
+
+func TestServiceBindingIndexers(t *testing.T) {
+	otherNs := "other-ns"
+	sbr := &v1alpha1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "index-ns", Name: "sbr"},
+		Spec: v1alpha1.ServiceBindingSpec{
+			Application: &v1alpha1.Application{
+				GroupVersionResource: metav1.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+				LocalObjectReference: corev1.LocalObjectReference{Name: "app"},
+			},
+			Services: []v1alpha1.Service{
+				{
+					GroupVersionKind:     metav1.GroupVersionKind{Group: "postgresql.baiju.dev", Version: "v1alpha1", Kind: "Database"},
+					LocalObjectReference: corev1.LocalObjectReference{Name: "db"},
+				},
+				{
+					GroupVersionKind:     metav1.GroupVersionKind{Version: "v1", Kind: "Secret"},
+					LocalObjectReference: corev1.LocalObjectReference{Name: "creds"},
+					Namespace:            &otherNs,
+				},
+			},
+		},
+		Status: v1alpha1.ServiceBindingStatus{Secret: "sbr-secret"},
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(sbr)
+	require.NoError(t, err)
+
+	indexers := serviceBindingIndexers()
+	keys := func(index string, obj interface{}) []string {
+		values, err := indexers[index](obj)
+		require.NoError(t, err)
+		return values
+	}
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	for _, obj := range []interface{}{sbr, &unstructured.Unstructured{Object: u}} {
+		require.Equal(t, []string{
+			"postgresql.baiju.dev/v1alpha1/Database/index-ns/db",
+			"v1/Secret/other-ns/creds",
+		}, keys(serviceIndex, obj))
+		require.Equal(t, []string{applicationIndexKey(deployments, "index-ns", "app")}, keys(applicationIndex, obj))
+		require.Empty(t, keys(applicationSelectorIndex, obj))
+		require.Equal(t, []string{"index-ns/sbr-secret"}, keys(secretIndex, obj))
+	}
+
+	_, err = indexers[serviceIndex](&corev1.Secret{})
+	require.Error(t, err)
+}
+
+func TestNewServiceBindingIndex(t *testing.T) {
+	informer := &controllertest.FakeInformer{Synced: true}
+	index := newServiceBindingIndex(informer)
+
+	newSBR := func(ns string, secret string) *unstructured.Unstructured {
+		sbr := &v1alpha1.ServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "binding"},
+			Status:     v1alpha1.ServiceBindingStatus{Secret: secret},
+		}
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(sbr)
+		require.NoError(t, err)
+		return &unstructured.Unstructured{Object: u}
+	}
+	lookupSecret := func(ns string, secret string) []*v1alpha1.ServiceBinding {
+		sbrs, err := index.lookup(secretIndex, secretIndexKey(ns, secret))
+		require.NoError(t, err)
+		return sbrs
+	}
+
+	// ServiceBindings of all watched namespaces are indexed as the informer reports them
+	first := newSBR("first", "first-secret")
+	informer.Add(first)
+	informer.Add(newSBR("second", "second-secret"))
+	require.Len(t, index.list(), 2)
+	require.Len(t, lookupSecret("first", "first-secret"), 1)
+
+	updated := newSBR("first", "renamed-secret")
+	informer.Update(first, updated)
+	require.Empty(t, lookupSecret("first", "first-secret"))
+	require.Len(t, lookupSecret("first", "renamed-secret"), 1)
+
+	informer.Delete(updated)
+	require.Empty(t, lookupSecret("first", "renamed-secret"))
+
+	// deletions missed by the informer are reported through tombstones
+	index.delete(toolscache.DeletedFinalStateUnknown{Key: "second/binding", Obj: newSBR("second", "second-secret")})
+	require.Empty(t, index.list())
+
+	var nilIndex *serviceBindingIndex
+	sbrs, err := nilIndex.lookup(serviceIndex, "any")
+	require.NoError(t, err)
+	require.Empty(t, sbrs)
+}
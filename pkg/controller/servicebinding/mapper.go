@@ -4,10 +4,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -22,8 +21,12 @@ var (
 // sbrRequestMapper is the handler.Mapper interface implementation. It should influence the
 // enqueue process considering the resources informed.
 type sbrRequestMapper struct {
-	client     dynamic.Interface
-	restMapper meta.RESTMapper
+	index        *serviceBindingIndex
+	restMapper   meta.RESTMapper
+	dependencies *dependencyTracker
+	namespaces   watchNamespaces
+	// shard owns the namespaces of the ServiceBindings to reconcile; all namespaces when nil.
+	shard shard
 }
 
 var serviceBindingRequestGVK = v1alpha1.SchemeGroupVersion.WithKind("ServiceBinding")
@@ -40,49 +43,17 @@ func isSecret(obj runtime.Object) bool {
 	return obj.GetObjectKind().GroupVersionKind() == secretGVK
 }
 
-// isSBRService checks whether the given obj is a service in given sbr.
-func isSBRService(sbr *v1alpha1.ServiceBinding, obj runtime.Object) bool {
-	for _, svc := range sbr.Spec.Services {
-		svcGVK := schema.GroupVersionKind{Group: svc.Group, Version: svc.Version, Kind: svc.Kind}
-		if obj.GetObjectKind().GroupVersionKind() == svcGVK {
-			return true
-		}
-	}
-	return false
-}
-
-// isSBRApplication checks whether the given obj is an application in given sbr.
-func isSBRApplication(
-	restMapper meta.RESTMapper,
-	app *v1alpha1.Application,
-	gvk schema.GroupVersionKind,
-	name string,
-) (bool, error) {
-	if app == nil {
-		return false, nil
+// isApplicationSelected checks whether the given labels are selected by the application's label
+// selector; applications without a selector select every object.
+func isApplicationSelected(app *v1alpha1.Application, objLabels map[string]string) (bool, error) {
+	if app == nil || app.LabelSelector == nil {
+		return true, nil
 	}
-	appGVR := schema.GroupVersionResource{
-		Group:    app.Group,
-		Version:  app.Version,
-		Resource: app.Resource,
-	}
-	appGVK, err := restMapper.KindFor(appGVR)
+	selector, err := metav1.LabelSelectorAsSelector(app.LabelSelector)
 	if err != nil {
 		return false, err
 	}
-
-	isEqual := gvk == appGVK
-
-	if len(app.Name) > 0 {
-		isEqual = app.Name == name
-	}
-
-	return isEqual, nil
-}
-
-// isSecretOwnedBySBR checks whether the given obj is a secret owned by the given sbr.
-func isSecretOwnedBySBR(obj metav1.Object, sbr *v1alpha1.ServiceBinding) bool {
-	return sbr.GetNamespace() == obj.GetNamespace() && sbr.Status.Secret == obj.GetName()
+	return selector.Matches(labels.Set(objLabels)), nil
 }
 
 // convertToSBR attempts to convert the given obj into a Service Binding.
@@ -124,13 +95,20 @@ func convertToRequests(t namespacedNameSet) []reconcile.Request {
 //
 // This method is responsible for ingesting arbitrary Kubernetes resources (for example corev1.Secret
 // or appsv1.Deployment) and lookup whether they are related to one or more existing Service Binding
-// Request resources.
+// Request resources. ServiceBindings are looked up in the informer cache indexes, so the cost of each
+// event depends only on the number of ServiceBindings related to the resource.
 func (m *sbrRequestMapper) Map(obj handler.MapObject) []reconcile.Request {
 	log := mapperLog.WithValues(
 		"Object.Namespace", obj.Meta.GetNamespace(),
 		"Object.Name", obj.Meta.GetName(),
 	)
 
+	// objects outside the watched namespaces are ignored; cluster scoped objects have none
+	if ns := obj.Meta.GetNamespace(); ns != "" && !m.namespaces.contains(ns) {
+		log.Trace("resource is outside the watched namespaces")
+		return []reconcile.Request{}
+	}
+
 	namespacedNamesToReconcile := make(namespacedNameSet)
 
 	if isServiceBinding(obj.Object) {
@@ -138,61 +116,41 @@ func (m *sbrRequestMapper) Map(obj handler.MapObject) []reconcile.Request {
 			{NamespacedName: convertToNamespacedName(obj.Meta)},
 		}
 		log.Debug("current resource is a SBR", "Requests", requests)
-		return requests
+		return ownedRequests(m.shard, requests)
 	}
 
-	// note(isutton): The client handles retries on the operator behalf, so only unrecoverable errors
-	// are left.
-	//
-	// please see https://github.com/isutton/service-binding-operator/blob/e17445570bd3889bcf7499142350a3b81463c6be/vendor/k8s.io/client-go/rest/request.go#L723-L812
-	sbrList, err := m.client.Resource(groupVersion).List(metav1.ListOptions{})
-	if err != nil {
-		log.Error(err, "listing SBRs")
-		return []reconcile.Request{}
-	}
-
-ITEMS:
-	for _, item := range sbrList.Items {
-		namespacedName := convertToNamespacedName(&item)
+	gvk := obj.Object.GetObjectKind().GroupVersionKind()
+	ns, name := obj.Meta.GetNamespace(), obj.Meta.GetName()
 
-		sbr, err := convertToSBR(item.Object)
-		if err != nil {
-			log.Error(err, "converting unstructured to SBR")
-			continue ITEMS
-		}
+	for _, n := range m.dependencies.dependentsOf(gvk, ns, name) {
+		log.Debug("resource identified as referenced by SBR", "NamespacedName", n)
+		namespacedNamesToReconcile.add(n)
+	}
 
-		if isSecret(obj.Object) && isSecretOwnedBySBR(obj.Meta, sbr) {
-			log.Debug("resource identified as a secret owned by the SBR")
-			namespacedNamesToReconcile.add(namespacedName)
-		} else {
-			log.Trace("resource is not a secret owned by the SBR")
-		}
+	if isSecret(obj.Object) {
+		m.addIndexed(log, namespacedNamesToReconcile, secretIndex, secretIndexKey(ns, name), nil,
+			"resource identified as a secret owned by the SBR")
+	}
 
-		if isSBRService(sbr, obj.Object) {
-			log.Debug("resource identified as service in SBR", "NamespacedName", namespacedName)
-			namespacedNamesToReconcile.add(namespacedName)
-		} else {
-			log.Trace("resource is not a service declared by the SBR")
-		}
+	m.addIndexed(log, namespacedNamesToReconcile, serviceIndex, serviceIndexKey(gvk, ns, name), nil,
+		"resource identified as service in SBR")
 
-		if ok, err := isSBRApplication(
-			m.restMapper,
-			sbr.Spec.Application,
-			obj.Object.GetObjectKind().GroupVersionKind(),
-			obj.Meta.GetName(),
-		); err != nil {
-			log.Error(err, "identifying resource as SBR application")
-			continue ITEMS
-		} else if !ok {
-			log.Trace("resource is not an application declared by the SBR")
-			continue ITEMS
-		} else {
-			log.Debug("resource identified as an application in SBR", "NamespacedName", namespacedName)
-			namespacedNamesToReconcile.add(namespacedName)
-		}
+	if mapping, err := m.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		log.Trace("resource is not an application declared by the SBR", "Error", err.Error())
+	} else {
+		m.addIndexed(log, namespacedNamesToReconcile,
+			applicationIndex, applicationIndexKey(mapping.Resource, ns, name), nil,
+			"resource identified as an application in SBR")
+		m.addIndexed(log, namespacedNamesToReconcile,
+			applicationSelectorIndex, applicationSelectorIndexKey(mapping.Resource, ns),
+			func(sbr *v1alpha1.ServiceBinding) (bool, error) {
+				return isApplicationSelected(sbr.Spec.Application, obj.Meta.GetLabels())
+			},
+			"resource identified as an application in SBR")
 	}
 
-	requests := convertToRequests(namespacedNamesToReconcile)
+	// ServiceBindings related to the resource can be owned by other replicas
+	requests := ownedRequests(m.shard, convertToRequests(namespacedNamesToReconcile))
 	if count := len(requests); count > 0 {
 		log.Debug("found SBRs for resource", "Count", count, "Requests", requests)
 	} else {
@@ -200,3 +158,33 @@ ITEMS:
 	}
 	return requests
 }
+
+// addIndexed adds to set the ServiceBindings found in the given index under key, and accepted by the
+// optional accept function.
+func (m *sbrRequestMapper) addIndexed(
+	logger *log.Log,
+	set namespacedNameSet,
+	index string,
+	key string,
+	accept func(sbr *v1alpha1.ServiceBinding) (bool, error),
+	msg string,
+) {
+	sbrs, err := m.index.lookup(index, key)
+	if err != nil {
+		logger.Error(err, "looking up SBRs", "Index", index, "Key", key)
+		return
+	}
+	for _, sbr := range sbrs {
+		if accept != nil {
+			if ok, err := accept(sbr); err != nil {
+				logger.Error(err, "evaluating SBR", "Index", index)
+				continue
+			} else if !ok {
+				continue
+			}
+		}
+		namespacedName := convertToNamespacedName(sbr)
+		logger.Debug(msg, "NamespacedName", namespacedName)
+		set.add(namespacedName)
+	}
+}
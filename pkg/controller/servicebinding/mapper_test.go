@@ -1,6 +1,7 @@
 package servicebinding
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -9,9 +10,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	toolscache "k8s.io/client-go/tools/cache"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/controller/servicebinding/binding"
 	"github.com/redhat-developer/service-binding-operator/pkg/testutils"
 	"github.com/redhat-developer/service-binding-operator/test/mocks"
 )
@@ -134,10 +140,9 @@ func TestSBRRequestMapperMap(t *testing.T) {
 		t.Run(tc.description, func(t *testing.T) {
 			f := tc.buildFakeFn()
 			mapObject := tc.buildMapObjectFn(f)
-			client := f.FakeDynClient()
 			restMapper := testutils.BuildTestRESTMapper()
 			mapper := &sbrRequestMapper{
-				client:     client,
+				index:      buildTestServiceBindingIndex(t, f),
 				restMapper: restMapper,
 			}
 			mappedRequests := mapper.Map(mapObject)
@@ -145,3 +150,253 @@ func TestSBRRequestMapperMap(t *testing.T) {
 		})
 	}
 }
+
+func TestSBRRequestMapperMapDependents(t *testing.T) {
+	sbrName := types.NamespacedName{Namespace: "mapper-unit", Name: "mapper-unit-sbr"}
+	serviceGVK := corev1.SchemeGroupVersion.WithKind("Service")
+
+	dependencies := newDependencyTracker()
+	dependencies.track(sbrName, []binding.ObjectReference{
+		{GroupVersionKind: serviceGVK, Namespace: "mapper-unit", Name: "mapper-unit-service"},
+	})
+
+	f := mocks.NewFake(t, reconcilerNs)
+	mapper := &sbrRequestMapper{
+		index:        buildTestServiceBindingIndex(t, f),
+		restMapper:   testutils.BuildTestRESTMapper(),
+		dependencies: dependencies,
+	}
+
+	mappedRequests := mapper.Map(handler.MapObject{
+		Meta: &metav1.ObjectMeta{
+			Namespace: "mapper-unit",
+			Name:      "mapper-unit-service",
+		},
+		Object: &corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Service",
+			},
+		},
+	})
+	require.Equal(t, []reconcile.Request{{NamespacedName: sbrName}}, mappedRequests)
+
+	mapper.shard = namespaceShard{}
+	mappedRequests = mapper.Map(handler.MapObject{
+		Meta:   &metav1.ObjectMeta{Namespace: "mapper-unit", Name: "mapper-unit-service"},
+		Object: &corev1.Service{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"}},
+	})
+	require.Empty(t, mappedRequests, "ServiceBindings owned by other replicas are left to them")
+}
+
+// newTestServiceBindingIndex returns an index containing the given ServiceBindings, stored as
+// unstructured objects like in the informer cache.
+func newTestServiceBindingIndex(t require.TestingT, sbrs ...*v1alpha1.ServiceBinding) *serviceBindingIndex {
+	indexer := toolscache.NewIndexer(toolscache.DeletionHandlingMetaNamespaceKeyFunc, serviceBindingIndexers())
+	for _, sbr := range sbrs {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(sbr)
+		require.NoError(t, err)
+		require.NoError(t, indexer.Add(&unstructured.Unstructured{Object: u}))
+	}
+	return &serviceBindingIndex{indexer: indexer}
+}
+
+// buildTestServiceBindingIndex returns an index containing the ServiceBindings known by f.
+func buildTestServiceBindingIndex(t *testing.T, f *mocks.Fake) *serviceBindingIndex {
+	list, err := f.FakeDynClient().Resource(groupVersion).List(metav1.ListOptions{})
+	require.NoError(t, err)
+	sbrs := make([]*v1alpha1.ServiceBinding, 0, len(list.Items))
+	for _, item := range list.Items {
+		sbr, err := convertToSBR(item.Object)
+		require.NoError(t, err)
+		sbrs = append(sbrs, sbr)
+	}
+	return newTestServiceBindingIndex(t, sbrs...)
+}
+
+func TestSBRRequestMapperMapIndexes(t *testing.T) {
+	ns := "mapper-index"
+	otherNs := "mapper-index-other"
+	deploymentsResource := metav1.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	secretService := func(name string, ns *string) v1alpha1.Service {
+		return v1alpha1.Service{
+			GroupVersionKind:     metav1.GroupVersionKind{Version: "v1", Kind: "Secret"},
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Namespace:            ns,
+		}
+	}
+
+	byName := &v1alpha1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "by-name"},
+		Spec: v1alpha1.ServiceBindingSpec{
+			Application: &v1alpha1.Application{
+				GroupVersionResource: deploymentsResource,
+				LocalObjectReference: corev1.LocalObjectReference{Name: "app"},
+			},
+			Services: []v1alpha1.Service{secretService("service-secret", nil)},
+		},
+		Status: v1alpha1.ServiceBindingStatus{Secret: "by-name-secret"},
+	}
+	bySelector := &v1alpha1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "by-selector"},
+		Spec: v1alpha1.ServiceBindingSpec{
+			Application: &v1alpha1.Application{
+				GroupVersionResource: deploymentsResource,
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"environment": "production"},
+				},
+			},
+			Services: []v1alpha1.Service{secretService("service-secret", &otherNs)},
+		},
+	}
+
+	mapper := &sbrRequestMapper{
+		index:      newTestServiceBindingIndex(t, byName, bySelector),
+		restMapper: testutils.BuildTestRESTMapper(),
+	}
+
+	deployment := func(name string, labels map[string]string) handler.MapObject {
+		return handler.MapObject{
+			Meta: &metav1.ObjectMeta{Namespace: ns, Name: name, Labels: labels},
+			Object: &appsv1.Deployment{
+				TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			},
+		}
+	}
+	secret := func(ns string, name string) handler.MapObject {
+		return handler.MapObject{
+			Meta:   &metav1.ObjectMeta{Namespace: ns, Name: name},
+			Object: &corev1.Secret{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}},
+		}
+	}
+	request := func(sbr *v1alpha1.ServiceBinding) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: sbr.Name}}
+	}
+
+	testCases := []struct {
+		description string
+		obj         handler.MapObject
+		expected    []reconcile.Request
+	}{
+		{
+			description: "application declared by name",
+			obj:         deployment("app", nil),
+			expected:    []reconcile.Request{request(byName)},
+		},
+		{
+			description: "application selected by labels",
+			obj:         deployment("other-app", map[string]string{"environment": "production"}),
+			expected:    []reconcile.Request{request(bySelector)},
+		},
+		{
+			description: "application not selected by labels",
+			obj:         deployment("other-app", map[string]string{"environment": "staging"}),
+			expected:    []reconcile.Request{},
+		},
+		{
+			description: "secret owned by the service binding",
+			obj:         secret(ns, "by-name-secret"),
+			expected:    []reconcile.Request{request(byName)},
+		},
+		{
+			description: "service in the service binding namespace",
+			obj:         secret(ns, "service-secret"),
+			expected:    []reconcile.Request{request(byName)},
+		},
+		{
+			description: "service in another namespace",
+			obj:         secret(otherNs, "service-secret"),
+			expected:    []reconcile.Request{request(bySelector)},
+		},
+		{
+			description: "unrelated secret",
+			obj:         secret(ns, "unrelated"),
+			expected:    []reconcile.Request{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.ElementsMatch(t, tc.expected, mapper.Map(tc.obj))
+		})
+	}
+
+	t.Run("objects outside the watched namespaces", func(t *testing.T) {
+		restricted := *mapper
+		restricted.namespaces = watchNamespaces{ns}
+		require.Empty(t, restricted.Map(secret(otherNs, "service-secret")))
+		require.Equal(t, []reconcile.Request{request(byName)}, restricted.Map(secret(ns, "service-secret")))
+	})
+}
+
+// BenchmarkSBRRequestMapperMap compares resolving an event by listing every ServiceBinding, as the
+// mapper used to do, with looking ServiceBindings up in the indexes.
+func BenchmarkSBRRequestMapperMap(b *testing.B) {
+	restMapper := testutils.BuildTestRESTMapper()
+	obj := handler.MapObject{
+		Meta: &metav1.ObjectMeta{Namespace: "mapper-bench", Name: "service-secret-0"},
+		Object: &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		},
+	}
+
+	for _, count := range []int{100, 1000, 5000} {
+		sbrs := make([]*v1alpha1.ServiceBinding, 0, count)
+		objs := make([]runtime.Object, 0, count)
+		for i := 0; i < count; i++ {
+			sbr := &v1alpha1.ServiceBinding{
+				TypeMeta: metav1.TypeMeta{Kind: "ServiceBinding", APIVersion: v1alpha1.SchemeGroupVersion.String()},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "mapper-bench",
+					Name:      fmt.Sprintf("sbr-%d", i),
+				},
+				Spec: v1alpha1.ServiceBindingSpec{
+					Application: &v1alpha1.Application{
+						GroupVersionResource: metav1.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+						LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("app-%d", i)},
+					},
+					Services: []v1alpha1.Service{{
+						GroupVersionKind:     metav1.GroupVersionKind{Version: "v1", Kind: "Secret"},
+						LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("service-secret-%d", i)},
+					}},
+				},
+			}
+			sbrs = append(sbrs, sbr)
+			u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(sbr)
+			require.NoError(b, err)
+			objs = append(objs, &unstructured.Unstructured{Object: u})
+		}
+
+		b.Run(fmt.Sprintf("list/%d", count), func(b *testing.B) {
+			client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), objs...)
+			svcGVK := obj.Object.GetObjectKind().GroupVersionKind()
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				list, err := client.Resource(groupVersion).List(metav1.ListOptions{})
+				require.NoError(b, err)
+				matches := 0
+				for _, item := range list.Items {
+					sbr, err := convertToSBR(item.Object)
+					require.NoError(b, err)
+					for _, svc := range sbr.Spec.Services {
+						if svc.Kind == svcGVK.Kind && svc.Name == obj.Meta.GetName() {
+							matches++
+						}
+					}
+				}
+				require.Equal(b, 1, matches)
+			}
+		})
+
+		b.Run(fmt.Sprintf("index/%d", count), func(b *testing.B) {
+			mapper := &sbrRequestMapper{
+				index:      newTestServiceBindingIndex(b, sbrs...),
+				restMapper: restMapper,
+			}
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				require.Len(b, mapper.Map(obj), 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package servicebinding
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/controller/servicebinding/binding"
+)
+
+// metricsNamespace prefixes all metrics exposed by the operator.
+const metricsNamespace = "service_binding_operator"
+
+const (
+	// serviceCollectionStage covers reading the services and their binding values.
+	serviceCollectionStage = "service_collection"
+	// secretWriteStage covers creating or updating the intermediate secret.
+	secretWriteStage = "secret_write"
+	// injectionStage covers binding or unbinding the application workloads.
+	injectionStage = "injection"
+)
+
+const (
+	// missingValueErrorType is used when the value of a binding annotation can't be found.
+	missingValueErrorType = "missing_value"
+	// accessDeniedErrorType is used when an object referenced from another namespace can't be read.
+	accessDeniedErrorType = "access_denied"
+	// notFoundErrorType is used when an object referenced by a binding annotation doesn't exist.
+	notFoundErrorType = "not_found"
+	// otherErrorType is used for all remaining annotation handler failures.
+	otherErrorType = "other"
+)
+
+const (
+	// permanentWatchType is used for watches kept for the whole operator lifetime.
+	permanentWatchType = "permanent"
+	// dynamicWatchType is used for watches stopped once no ServiceBinding or ClusterServiceVersion
+	// needs them anymore.
+	dynamicWatchType = "dynamic"
+)
+
+const (
+	// successResult is used for reconciliations which succeeded.
+	successResult = "success"
+	// errorResult is used for reconciliations which failed, and are retried after a backoff.
+	errorResult = "error"
+	// requeueResult is used for reconciliations asking to be retried after a backoff.
+	requeueResult = "requeue"
+	// requeueAfterResult is used for reconciliations asking to be retried after a given delay.
+	requeueAfterResult = "requeue_after"
+)
+
+var (
+	// bindingConditionsGauge counts the ServiceBindings in each condition state per namespace.
+	bindingConditionsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "bindings",
+			Help:      "Number of ServiceBindings per condition and status.",
+		},
+		[]string{"namespace", "condition", "status"},
+	)
+
+	// reconcileStageDuration observes how long each reconciliation stage takes.
+	reconcileStageDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "reconcile_stage_duration_seconds",
+			Help:      "Duration of ServiceBinding reconciliation stages.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"stage"},
+	)
+
+	// workloadUpdatesTotal counts the application workloads updated by the operator.
+	workloadUpdatesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "workload_updates_total",
+			Help:      "Number of application workloads updated while binding or unbinding.",
+		},
+		[]string{"kind", "operation"},
+	)
+
+	// rolloutsTriggeredTotal counts the workload updates which changed the pod template, and
+	// therefore triggered a new rollout.
+	rolloutsTriggeredTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "rollouts_triggered_total",
+			Help:      "Number of application workload rollouts triggered by pod template changes.",
+		},
+		[]string{"kind"},
+	)
+
+	// annotationFailuresTotal counts the binding annotations which couldn't be processed.
+	annotationFailuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "annotation_handler_failures_total",
+			Help:      "Number of binding annotation handler failures per error type.",
+		},
+		[]string{"error_type"},
+	)
+
+	// reconcilesTotal counts the ServiceBinding reconciliations per result.
+	reconcilesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "reconciles_total",
+			Help:      "Number of ServiceBinding reconciliations per result.",
+		},
+		[]string{"result"},
+	)
+
+	// activeWatchesGauge counts the kinds watched by the operator.
+	activeWatchesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "active_watches",
+			Help:      "Number of kinds watched by the operator, per watch type.",
+		},
+		[]string{"type"},
+	)
+
+	// watchOwnersGauge counts the ServiceBindings and ClusterServiceVersions using each dynamic
+	// watch.
+	watchOwnersGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "watch_owners",
+			Help:      "Number of ServiceBindings and ClusterServiceVersions using each dynamic watch.",
+		},
+		[]string{"group", "version", "kind"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		bindingConditionsGauge,
+		reconcileStageDuration,
+		workloadUpdatesTotal,
+		rolloutsTriggeredTotal,
+		annotationFailuresTotal,
+		reconcilesTotal,
+		activeWatchesGauge,
+		watchOwnersGauge,
+	)
+}
+
+// observedConditions are the condition types exported by bindingConditionsGauge.
+var observedConditions = []conditionsv1.ConditionType{BindingReady, CollectionReady, InjectionReady}
+
+// conditionStates keeps the last observed status of each ServiceBinding's conditions, so the gauge
+// can be adjusted when a status changes or a ServiceBinding is removed.
+type conditionStates struct {
+	mu     sync.Mutex
+	states map[types.NamespacedName]map[conditionsv1.ConditionType]string
+}
+
+// bindingConditions holds the condition states of all ServiceBindings observed by the operator.
+var bindingConditions = &conditionStates{
+	states: make(map[types.NamespacedName]map[conditionsv1.ConditionType]string),
+}
+
+// observe updates the gauge with the conditions of the given ServiceBinding.
+func (c *conditionStates) observe(sbr *v1alpha1.ServiceBinding) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	nn := types.NamespacedName{Namespace: sbr.GetNamespace(), Name: sbr.GetName()}
+	c.forgetLocked(nn)
+
+	state := make(map[conditionsv1.ConditionType]string)
+	for _, t := range observedConditions {
+		cond := conditionsv1.FindStatusCondition(sbr.Status.Conditions, t)
+		if cond == nil {
+			continue
+		}
+		status := string(cond.Status)
+		state[t] = status
+		bindingConditionsGauge.WithLabelValues(nn.Namespace, string(t), status).Inc()
+	}
+	c.states[nn] = state
+}
+
+// forget removes the given ServiceBinding from the gauge.
+func (c *conditionStates) forget(nn types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.forgetLocked(nn)
+}
+
+func (c *conditionStates) forgetLocked(nn types.NamespacedName) {
+	for t, status := range c.states[nn] {
+		bindingConditionsGauge.WithLabelValues(nn.Namespace, string(t), status).Dec()
+	}
+	delete(c.states, nn)
+}
+
+// reconcileResult returns the result of a reconciliation returning the given result and error.
+func reconcileResult(res reconcile.Result, err error) string {
+	switch {
+	case err != nil:
+		return errorResult
+	case res.RequeueAfter > 0:
+		return requeueAfterResult
+	case res.Requeue:
+		return requeueResult
+	default:
+		return successResult
+	}
+}
+
+// observeStageDuration records the time elapsed since start for the given reconciliation stage.
+func observeStageDuration(stage string, start time.Time) {
+	reconcileStageDuration.WithLabelValues(stage).Observe(time.Since(start).Seconds())
+}
+
+// countWorkloadUpdate records an update of the given workload, and whether it triggered a rollout
+// by changing the pod template.
+func countWorkloadUpdate(before *unstructured.Unstructured, after *unstructured.Unstructured, operation string) {
+	kind := after.GetKind()
+	workloadUpdatesTotal.WithLabelValues(kind, operation).Inc()
+
+	beforeTemplate, _, _ := unstructured.NestedMap(before.Object, "spec", "template")
+	afterTemplate, found, _ := unstructured.NestedMap(after.Object, "spec", "template")
+	if found && !nestedMapComparison(beforeTemplate, afterTemplate).Success {
+		rolloutsTriggeredTotal.WithLabelValues(kind).Inc()
+	}
+}
+
+// annotationErrorType classifies errors returned by binding annotation handlers.
+func annotationErrorType(err error) string {
+	var missing *binding.ErrMissingValue
+	var denied *binding.ErrAccessDenied
+	switch {
+	case errors.As(err, &missing):
+		return missingValueErrorType
+	case errors.As(err, &denied):
+		return accessDeniedErrorType
+	case k8serrors.IsNotFound(err):
+		return notFoundErrorType
+	default:
+		return otherErrorType
+	}
+}
+
+// countAnnotationFailure records a binding annotation handler failure.
+func countAnnotationFailure(err error) {
+	annotationFailuresTotal.WithLabelValues(annotationErrorType(err)).Inc()
+}
+
+// observeWatchOwners records the number of owners of the dynamic watch on gvk, removing the watch
+// from the gauge when it has none.
+func observeWatchOwners(gvk schema.GroupVersionKind, owners int) {
+	if owners == 0 {
+		watchOwnersGauge.DeleteLabelValues(gvk.Group, gvk.Version, gvk.Kind)
+		return
+	}
+	watchOwnersGauge.WithLabelValues(gvk.Group, gvk.Version, gvk.Kind).Set(float64(owners))
+}
@@ -0,0 +1,104 @@
+package servicebinding
+
+import (
+	"errors"
+	"testing"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/controller/servicebinding/binding"
+)
+
+// stageSampleCount returns how many times the duration of the given reconciliation stage has been
+// observed.
+func stageSampleCount(t *testing.T, stage string) uint64 {
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(reconcileStageDuration))
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "stage" && l.GetValue() == stage {
+					return m.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestBindingConditionsGauge(t *testing.T) {
+	ns := "metrics-conditions"
+	sbr := &v1alpha1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "binding"},
+	}
+	gauge := func(condition conditionsv1.ConditionType, status corev1.ConditionStatus) float64 {
+		return testutil.ToFloat64(
+			bindingConditionsGauge.WithLabelValues(ns, string(condition), string(status)))
+	}
+	setConditions := func(status corev1.ConditionStatus) {
+		for _, t := range observedConditions {
+			conditionsv1.SetStatusCondition(&sbr.Status.Conditions, conditionsv1.Condition{
+				Type:   t,
+				Status: status,
+			})
+		}
+	}
+
+	setConditions(corev1.ConditionFalse)
+	bindingConditions.observe(sbr)
+	require.Equal(t, float64(1), gauge(BindingReady, corev1.ConditionFalse))
+	require.Equal(t, float64(1), gauge(CollectionReady, corev1.ConditionFalse))
+
+	setConditions(corev1.ConditionTrue)
+	bindingConditions.observe(sbr)
+	require.Equal(t, float64(0), gauge(BindingReady, corev1.ConditionFalse))
+	require.Equal(t, float64(1), gauge(BindingReady, corev1.ConditionTrue))
+	require.Equal(t, float64(1), gauge(InjectionReady, corev1.ConditionTrue))
+
+	bindingConditions.forget(types.NamespacedName{Namespace: ns, Name: "binding"})
+	require.Equal(t, float64(0), gauge(BindingReady, corev1.ConditionTrue))
+	require.Equal(t, float64(0), gauge(InjectionReady, corev1.ConditionTrue))
+}
+
+func TestCountWorkloadUpdate(t *testing.T) {
+	kind := "MetricsWorkload"
+	before := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind": kind,
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{"spec": map[string]interface{}{}},
+		},
+	}}
+
+	unchanged := before.DeepCopy()
+	unchanged.SetAnnotations(map[string]string{"foo": "bar"})
+	countWorkloadUpdate(before, unchanged, "bind")
+	require.Equal(t, float64(1), testutil.ToFloat64(workloadUpdatesTotal.WithLabelValues(kind, "bind")))
+	require.Equal(t, float64(0), testutil.ToFloat64(rolloutsTriggeredTotal.WithLabelValues(kind)))
+
+	changed := before.DeepCopy()
+	require.NoError(t, unstructured.SetNestedField(
+		changed.Object, []interface{}{}, "spec", "template", "spec", "containers"))
+	countWorkloadUpdate(before, changed, "bind")
+	require.Equal(t, float64(2), testutil.ToFloat64(workloadUpdatesTotal.WithLabelValues(kind, "bind")))
+	require.Equal(t, float64(1), testutil.ToFloat64(rolloutsTriggeredTotal.WithLabelValues(kind)))
+}
+
+func TestAnnotationErrorType(t *testing.T) {
+	require.Equal(t, missingValueErrorType, annotationErrorType(&binding.ErrMissingValue{}))
+	require.Equal(t, accessDeniedErrorType, annotationErrorType(&binding.ErrAccessDenied{}))
+	require.Equal(t, notFoundErrorType, annotationErrorType(
+		k8serrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "creds")))
+	require.Equal(t, otherErrorType, annotationErrorType(errors.New("boom")))
+}
@@ -0,0 +1,169 @@
+package servicebinding
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/log"
+)
+
+var (
+	modifierLog = log.NewLog("modifier")
+	// errEmptyModifierName is returned when a modifier is registered without a name.
+	errEmptyModifierName = errors.New("extra fields modifier name is empty")
+)
+
+// ExtraFieldsModifier is useful for updating application workloads which require additional changes
+// besides env/volumes updating. eg. for knative service we need to remove or update
+// `spec.template.metadata.name` from service template before updating otherwise it will be rejected.
+type ExtraFieldsModifier interface {
+	ModifyExtraFields(u *unstructured.Unstructured) error
+}
+
+// ExtraFieldsModifierFunc func receiver type for ExtraFieldsModifier
+type ExtraFieldsModifierFunc func(u *unstructured.Unstructured) error
+
+// ModifyExtraFields implements ExtraFieldsModifier interface
+func (f ExtraFieldsModifierFunc) ModifyExtraFields(u *unstructured.Unstructured) error {
+	return f(u)
+}
+
+// ExtraFieldsModifierError is returned when one of the modifiers registered for an application
+// workload fails; it carries the name of the failing modifier, so it can be reported in the Service
+// Binding status.
+type ExtraFieldsModifierError struct {
+	// Modifier is the name the failing modifier has been registered with.
+	Modifier string
+	// GVK is the application workload's GroupVersionKind.
+	GVK schema.GroupVersionKind
+	// Name is the application workload's name.
+	Name string
+	// Err is the error returned by the modifier.
+	Err error
+}
+
+func (e *ExtraFieldsModifierError) Error() string {
+	return fmt.Sprintf(
+		"extra fields modifier %q failed on %s %q: %s", e.Modifier, e.GVK.Kind, e.Name, e.Err)
+}
+
+// Unwrap returns the error returned by the modifier.
+func (e *ExtraFieldsModifierError) Unwrap() error {
+	return e.Err
+}
+
+// namedExtraFieldsModifier is a modifier and the name it has been registered with.
+type namedExtraFieldsModifier struct {
+	name     string
+	modifier ExtraFieldsModifier
+}
+
+// extraFieldsModifierChain executes each modifier in registration order, stopping at the first
+// modifier returning an error.
+type extraFieldsModifierChain []namedExtraFieldsModifier
+
+// ModifyExtraFields implements ExtraFieldsModifier interface
+func (c extraFieldsModifierChain) ModifyExtraFields(u *unstructured.Unstructured) error {
+	for _, m := range c {
+		modifierLog.Debug("Executing extra fields modifier",
+			"Modifier", m.name, "Obj.Kind", u.GetKind(), "Obj.Name", u.GetName())
+		if err := m.modifier.ModifyExtraFields(u); err != nil {
+			return &ExtraFieldsModifierError{
+				Modifier: m.name,
+				GVK:      u.GroupVersionKind(),
+				Name:     u.GetName(),
+				Err:      err,
+			}
+		}
+	}
+	return nil
+}
+
+// extraFieldsModifierRegistry keeps the ordered chain of modifiers registered for each application
+// GroupVersionResource.
+type extraFieldsModifierRegistry struct {
+	mu        sync.RWMutex
+	modifiers map[schema.GroupVersionResource]extraFieldsModifierChain
+}
+
+// register appends the given modifier to the chain of the given GVR; a modifier name can be
+// registered only once per GVR.
+func (r *extraFieldsModifierRegistry) register(
+	gvr schema.GroupVersionResource,
+	name string,
+	modifier ExtraFieldsModifier,
+) error {
+	if len(name) == 0 {
+		return errEmptyModifierName
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range r.modifiers[gvr] {
+		if m.name == name {
+			return fmt.Errorf("extra fields modifier %q is already registered for %s", name, gvr)
+		}
+	}
+	r.modifiers[gvr] = append(r.modifiers[gvr], namedExtraFieldsModifier{name: name, modifier: modifier})
+	return nil
+}
+
+// forGVR returns the chain of modifiers registered for the given GVR, or nil when there are none.
+func (r *extraFieldsModifierRegistry) forGVR(gvr schema.GroupVersionResource) ExtraFieldsModifier {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	chain, ok := r.modifiers[gvr]
+	if !ok || len(chain) == 0 {
+		return nil
+	}
+	// copy the chain, so later registrations do not affect binders already built
+	return append(extraFieldsModifierChain{}, chain...)
+}
+
+// newExtraFieldsModifierRegistry returns an empty registry.
+func newExtraFieldsModifierRegistry() *extraFieldsModifierRegistry {
+	return &extraFieldsModifierRegistry{
+		modifiers: make(map[schema.GroupVersionResource]extraFieldsModifierChain),
+	}
+}
+
+// extraFieldsModifiers is the registry consulted when building binders.
+var extraFieldsModifiers = newExtraFieldsModifierRegistry()
+
+// RegisterExtraFieldsModifier registers a modifier to be executed, before updating, on application
+// workloads of the given GVR. Modifiers are executed in registration order, and the name is used to
+// report failures in the Service Binding status. It is meant to be called at operator startup,
+// before controllers are added to the manager.
+func RegisterExtraFieldsModifier(
+	gvr schema.GroupVersionResource,
+	name string,
+	modifier ExtraFieldsModifier,
+) error {
+	return extraFieldsModifiers.register(gvr, name, modifier)
+}
+
+// knativeRevisionNameModifier removes the revision name from Knative services' template, otherwise
+// the update would be rejected since a revision with that name already exists.
+func knativeRevisionNameModifier(u *unstructured.Unstructured) error {
+	pathToRevisionName := strings.Split("spec.template.metadata.name", ".")
+	revisionName, ok, err := unstructured.NestedString(u.Object, pathToRevisionName...)
+	if err == nil && ok {
+		modifierLog.Info("remove revision in knative service template", "name", revisionName)
+		unstructured.RemoveNestedField(u.Object, pathToRevisionName...)
+	}
+	return nil
+}
+
+func init() {
+	ksvcGVR := knativev1.SchemeGroupVersion.WithResource("services")
+	err := RegisterExtraFieldsModifier(
+		ksvcGVR, "knative-revision-name", ExtraFieldsModifierFunc(knativeRevisionNameModifier))
+	if err != nil {
+		panic(err)
+	}
+}
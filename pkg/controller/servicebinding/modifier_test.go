@@ -0,0 +1,77 @@
+package servicebinding
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestExtraFieldsModifierRegistry(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "workloads"}
+
+	newWorkload := func() *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Workload"})
+		u.SetName("workload")
+		return u
+	}
+
+	t.Run("no modifiers registered", func(t *testing.T) {
+		r := newExtraFieldsModifierRegistry()
+		require.Nil(t, r.forGVR(gvr))
+	})
+
+	t.Run("modifiers are executed in registration order", func(t *testing.T) {
+		r := newExtraFieldsModifierRegistry()
+		var executed []string
+		for _, name := range []string{"first", "second", "third"} {
+			name := name
+			err := r.register(gvr, name, ExtraFieldsModifierFunc(func(u *unstructured.Unstructured) error {
+				executed = append(executed, name)
+				return nil
+			}))
+			require.NoError(t, err)
+		}
+
+		m := r.forGVR(gvr)
+		require.NotNil(t, m)
+		require.NoError(t, m.ModifyExtraFields(newWorkload()))
+		require.Equal(t, []string{"first", "second", "third"}, executed)
+	})
+
+	t.Run("duplicated and empty names are rejected", func(t *testing.T) {
+		r := newExtraFieldsModifierRegistry()
+		noop := ExtraFieldsModifierFunc(func(u *unstructured.Unstructured) error { return nil })
+		require.NoError(t, r.register(gvr, "noop", noop))
+		require.Error(t, r.register(gvr, "noop", noop))
+		require.Equal(t, errEmptyModifierName, r.register(gvr, "", noop))
+	})
+
+	t.Run("failing modifier stops the chain and is reported", func(t *testing.T) {
+		r := newExtraFieldsModifierRegistry()
+		modifierErr := errors.New("restartNonce is not a string")
+		require.NoError(t, r.register(gvr, "bump-restart-nonce",
+			ExtraFieldsModifierFunc(func(u *unstructured.Unstructured) error {
+				return modifierErr
+			})))
+		require.NoError(t, r.register(gvr, "never-called",
+			ExtraFieldsModifierFunc(func(u *unstructured.Unstructured) error {
+				t.Fatal("modifier should not be called")
+				return nil
+			})))
+
+		err := r.forGVR(gvr).ModifyExtraFields(newWorkload())
+		require.Error(t, err)
+		require.True(t, errors.Is(err, modifierErr))
+
+		var e *ExtraFieldsModifierError
+		require.True(t, errors.As(err, &e))
+		require.Equal(t, "bump-restart-nonce", e.Modifier)
+		require.Equal(t, "workload", e.Name)
+		require.Equal(t, ExtraFieldsModifierFailedReason, injectionFailureReason(err))
+		require.Equal(t, bindingFail, injectionFailureReason(modifierErr))
+	})
+}
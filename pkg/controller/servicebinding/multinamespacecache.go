@@ -0,0 +1,140 @@
+package servicebinding
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/log"
+)
+
+var (
+	cacheLog = log.NewLog("cache")
+)
+
+// NewMultiNamespaceCache returns a cache.NewCacheFunc building a cache restricted to the given
+// namespaces on top of controller-runtime's multi-namespace cache. Cluster scoped objects, which the
+// cache of each namespace would otherwise hold again, are held once by an extra cache. It's meant to
+// be the manager's NewCache when several namespaces are watched.
+func NewMultiNamespaceCache(namespaces []string) cache.NewCacheFunc {
+	newNamespacedCache := cache.MultiNamespacedCacheBuilder(namespaces)
+	return func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+		if len(namespaces) == 0 {
+			return nil, fmt.Errorf("no namespace to watch")
+		}
+		namespaced, err := newNamespacedCache(config, opts)
+		if err != nil {
+			return nil, err
+		}
+		opts.Namespace = ""
+		cluster, err := cache.New(config, opts)
+		if err != nil {
+			return nil, err
+		}
+		return &multiNamespaceCache{
+			Cache:      namespaced,
+			cluster:    cluster,
+			scheme:     opts.Scheme,
+			restMapper: opts.Mapper,
+		}, nil
+	}
+}
+
+// multiNamespaceCache is a cache.Cache restricted to several namespaces, serving cluster scoped
+// kinds from the cluster cache.
+type multiNamespaceCache struct {
+	// Cache holds the objects of namespaced kinds, with a cache per namespace.
+	cache.Cache
+	cluster    cache.Cache
+	scheme     *runtime.Scheme
+	restMapper meta.RESTMapper
+}
+
+var _ cache.Cache = (*multiNamespaceCache)(nil)
+
+// cacheForKind returns the cluster cache for cluster scoped kinds, and the per-namespace caches
+// otherwise.
+func (c *multiNamespaceCache) cacheForKind(gvk schema.GroupVersionKind) (cache.Cache, error) {
+	mapping, err := c.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+		return c.cluster, nil
+	}
+	return c.Cache, nil
+}
+
+// cacheFor returns the cache holding the given object, or the items of the given list.
+func (c *multiNamespaceCache) cacheFor(obj runtime.Object) (cache.Cache, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	if meta.IsListType(obj) {
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	}
+	return c.cacheForKind(gvk)
+}
+
+func (c *multiNamespaceCache) GetInformer(obj runtime.Object) (cache.Informer, error) {
+	objCache, err := c.cacheFor(obj)
+	if err != nil {
+		return nil, err
+	}
+	return objCache.GetInformer(obj)
+}
+
+func (c *multiNamespaceCache) GetInformerForKind(gvk schema.GroupVersionKind) (cache.Informer, error) {
+	kindCache, err := c.cacheForKind(gvk)
+	if err != nil {
+		return nil, err
+	}
+	return kindCache.GetInformerForKind(gvk)
+}
+
+func (c *multiNamespaceCache) Start(stop <-chan struct{}) error {
+	go func() {
+		if err := c.cluster.Start(stop); err != nil {
+			cacheLog.Error(err, "on starting the cluster cache")
+		}
+	}()
+	return c.Cache.Start(stop)
+}
+
+func (c *multiNamespaceCache) WaitForCacheSync(stop <-chan struct{}) bool {
+	clusterSynced := c.cluster.WaitForCacheSync(stop)
+	return c.Cache.WaitForCacheSync(stop) && clusterSynced
+}
+
+func (c *multiNamespaceCache) IndexField(obj runtime.Object, field string, extractValue client.IndexerFunc) error {
+	objCache, err := c.cacheFor(obj)
+	if err != nil {
+		return err
+	}
+	return objCache.IndexField(obj, field, extractValue)
+}
+
+func (c *multiNamespaceCache) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	objCache, err := c.cacheFor(obj)
+	if err != nil {
+		return err
+	}
+	return objCache.Get(ctx, key, obj)
+}
+
+func (c *multiNamespaceCache) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	listCache, err := c.cacheFor(list)
+	if err != nil {
+		return err
+	}
+	return listCache.List(ctx, list, opts...)
+}
@@ -0,0 +1,91 @@
+package servicebinding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// readerCache is a cache.Cache reading objects from a client.
+type readerCache struct {
+	*informertest.FakeInformers
+	reader client.Reader
+}
+
+var _ cache.Cache = (*readerCache)(nil)
+
+func (c *readerCache) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	return c.reader.Get(ctx, key, obj)
+}
+
+func (c *readerCache) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
+
+func TestMultiNamespaceCache(t *testing.T) {
+	namespaceGVK := corev1.SchemeGroupVersion.WithKind("Namespace")
+	restMapper := buildCachedClientTestRESTMapper().(*meta.DefaultRESTMapper)
+	restMapper.Add(namespaceGVK, meta.RESTScopeRoot)
+
+	namespaced := &readerCache{
+		FakeInformers: &informertest.FakeInformers{Scheme: scheme.Scheme},
+		reader: fake.NewFakeClientWithScheme(scheme.Scheme,
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "first", Name: "config"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "second", Name: "config"}},
+		),
+	}
+	cluster := &readerCache{
+		FakeInformers: &informertest.FakeInformers{Scheme: scheme.Scheme},
+		reader: fake.NewFakeClientWithScheme(scheme.Scheme,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "first"}},
+		),
+	}
+	c := &multiNamespaceCache{
+		Cache:      namespaced,
+		cluster:    cluster,
+		scheme:     scheme.Scheme,
+		restMapper: restMapper,
+	}
+	ctx := context.TODO()
+
+	t.Run("namespaced objects are read from the namespaced caches", func(t *testing.T) {
+		configMap := &corev1.ConfigMap{}
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "second", Name: "config"}, configMap))
+		require.Equal(t, "second", configMap.Namespace)
+
+		list := &corev1.ConfigMapList{}
+		require.NoError(t, c.List(ctx, list))
+		require.Len(t, list.Items, 2)
+	})
+
+	t.Run("cluster scoped objects are read from the cluster cache", func(t *testing.T) {
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "first"}, &corev1.Namespace{}))
+
+		list := &corev1.NamespaceList{}
+		require.NoError(t, c.List(ctx, list))
+		require.Len(t, list.Items, 1)
+	})
+
+	t.Run("informers are taken from the cache holding the kind", func(t *testing.T) {
+		_, err := c.GetInformer(&corev1.ConfigMap{})
+		require.NoError(t, err)
+		_, err = c.GetInformerForKind(namespaceGVK)
+		require.NoError(t, err)
+
+		configMapGVK := corev1.SchemeGroupVersion.WithKind("ConfigMap")
+		require.Contains(t, namespaced.InformersByGVK, configMapGVK)
+		require.NotContains(t, namespaced.InformersByGVK, namespaceGVK)
+		require.Contains(t, cluster.InformersByGVK, namespaceGVK)
+		require.NotContains(t, cluster.InformersByGVK, configMapGVK)
+	})
+}
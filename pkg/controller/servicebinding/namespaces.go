@@ -0,0 +1,62 @@
+package servicebinding
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/config"
+)
+
+// watchNamespaces are the namespaces the operator is restricted to; empty when the operator watches
+// all namespaces.
+type watchNamespaces []string
+
+// getWatchNamespaces returns the namespaces of the operator configuration.
+func getWatchNamespaces() watchNamespaces {
+	return config.Get().WatchNamespaces
+}
+
+// contains returns whether objects in the given namespace are watched.
+func (w watchNamespaces) contains(ns string) bool {
+	if len(w) == 0 {
+		return true
+	}
+	for _, watched := range w {
+		if watched == ns {
+			return true
+		}
+	}
+	return false
+}
+
+// listed returns the namespaces to list or watch objects in, one at a time; all namespaces are
+// represented by the empty namespace.
+func (w watchNamespaces) listed() []string {
+	if len(w) == 0 {
+		return []string{""}
+	}
+	return w
+}
+
+// shard is the share of namespaces whose ServiceBindings are reconciled by the operator replica.
+type shard interface {
+	Owns(ns string) bool
+}
+
+// ownsNamespace returns whether the given shard owns the given namespace; nil owns all namespaces.
+func ownsNamespace(s shard, ns string) bool {
+	return s == nil || s.Owns(ns)
+}
+
+// ownedRequests returns the given requests for ServiceBindings in namespaces the shard owns.
+func ownedRequests(s shard, requests []reconcile.Request) []reconcile.Request {
+	if s == nil {
+		return requests
+	}
+	owned := make([]reconcile.Request, 0, len(requests))
+	for _, req := range requests {
+		if s.Owns(req.Namespace) {
+			owned = append(owned, req)
+		}
+	}
+	return owned
+}
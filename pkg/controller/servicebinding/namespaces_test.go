@@ -0,0 +1,38 @@
+package servicebinding
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestWatchNamespaces(t *testing.T) {
+	defer os.Setenv("WATCH_NAMESPACE", os.Getenv("WATCH_NAMESPACE"))
+	require.NoError(t, os.Setenv("WATCH_NAMESPACE", "tenant-a,tenant-b"))
+
+	namespaces := getWatchNamespaces()
+	require.True(t, namespaces.contains("tenant-b"))
+	require.False(t, namespaces.contains("other"))
+	require.Equal(t, []string{"tenant-a", "tenant-b"}, namespaces.listed())
+
+	var all watchNamespaces
+	require.True(t, all.contains("other"))
+	require.Equal(t, []string{""}, all.listed())
+}
+
+// namespaceShard is a shard owning the namespaces set to true.
+type namespaceShard map[string]bool
+
+func (s namespaceShard) Owns(ns string) bool {
+	return s[ns]
+}
+
+func TestOwnedRequests(t *testing.T) {
+	requests := []reconcile.Request{request("owned", "sbr"), request("other", "sbr")}
+	require.Equal(t, requests, ownedRequests(nil, requests), "all namespaces are owned without sharding")
+	require.Equal(t, requests[:1], ownedRequests(namespaceShard{"owned": true}, requests))
+	require.True(t, ownsNamespace(nil, "other"))
+	require.False(t, ownsNamespace(namespaceShard{"owned": true}, "other"))
+}
@@ -20,6 +20,8 @@ func (a accumulator) Accumulate(val interface{}) error {
 		b[valuesKey] = []string{v}
 	case int:
 		b[valuesKey] = []int{v}
+	case int64, float64, bool: // found in unstructured objects
+		b[valuesKey] = []interface{}{v}
 	case []map[string]interface{}, []string, []int:
 		b[valuesKey] = v
 	default:
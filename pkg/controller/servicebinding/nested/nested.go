@@ -45,6 +45,7 @@ var unsupportedTypeErr = errors.New("unsupported type")
 // convertToSlice attempts to convert the given `src` into a `[]interface{}`. An
 // error is returned when `src` is not one of the following:
 //
+// - []interface{}
 // - []map[string]interface{}
 // - []string
 // - []int
@@ -52,6 +53,8 @@ var unsupportedTypeErr = errors.New("unsupported type")
 func convertToSlice(src interface{}) ([]interface{}, error) {
 	var obj []interface{}
 	switch t := src.(type) {
+	case []interface{}:
+		obj = t
 	case []map[string]interface{}:
 		obj = make([]interface{}, len(t))
 		for i, e := range t {
@@ -114,14 +117,16 @@ func getValue(obj interface{}, p path) (interface{}, bool, error) {
 	}
 
 	switch val := obj.(type) {
-	case string, int: // scalar
+	case nil: // null
+		return nil, false, nil
+	case string, int, int64, float64, bool: // scalar
 		if p.hasTail() {
 			return nil, false, fmt.Errorf("type doesn't accept an index or key")
 		}
 		return val, true, nil
 	case map[string]interface{}: // map
 		return getValueFromMap(val, p)
-	case []map[string]interface{}, []int, []string: // slice
+	case []interface{}, []map[string]interface{}, []int, []string: // slice
 		return getValueFromSlice(val, p)
 	default:
 		panic(fmt.Sprintf("missing type for %+v", val))
@@ -273,4 +273,47 @@ func TestGetValue(t *testing.T) {
 			},
 		},
 	}))
+
+	t.Run("unstructured spec.ports.port", assertGetValue(args{
+		path: "spec.ports.port",
+		src: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"ports": []interface{}{
+					map[string]interface{}{"name": "http", "port": int64(8080)},
+					map[string]interface{}{"name": "https", "port": int64(8443)},
+				},
+			},
+		},
+		expected: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"ports": map[string]interface{}{
+					"port": []interface{}{int64(8080), int64(8443)},
+				},
+			},
+		},
+	}))
+
+	t.Run("unstructured spec.rules.0.host", assertGetValue(args{
+		path: "spec.rules.0.host",
+		src: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"rules": []interface{}{
+					map[string]interface{}{"host": "example.com"},
+				},
+			},
+		},
+		expected: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"rules": map[string]interface{}{
+					"host": "example.com",
+				},
+			},
+		},
+	}))
+
+	t.Run("null value is not found", func(t *testing.T) {
+		_, found, err := GetValue(map[string]interface{}{"key": nil}, "key.subKey", "key")
+		require.NoError(t, err)
+		require.False(t, found)
+	})
 }
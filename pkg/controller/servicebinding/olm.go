@@ -14,7 +14,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
 
+	"github.com/redhat-developer/service-binding-operator/pkg/controller/servicebinding/binding"
 	"github.com/redhat-developer/service-binding-operator/pkg/log"
 )
 
@@ -130,7 +132,7 @@ func (o *olm) selectCRDByGVK(gvk schema.GroupVersionKind, crd *unstructured.Unst
 	// CRDDescription is both used by OLM to configure OLM descriptors in manifests existing in the
 	// cluster but is also built from annotations present in the CRD
 	if crd != nil {
-		crdDescription, err = buildCRDDescriptionFromCRD(crd)
+		crdDescription, err = buildCRDDescriptionFromCRD(crd, gvk.Version)
 		if err != nil {
 			return nil, err
 		}
@@ -172,54 +174,97 @@ func (o *olm) selectCRDByGVK(gvk schema.GroupVersionKind, crd *unstructured.Unst
 	return crdDescriptions[0], nil
 }
 
-// buildCRDDescriptionFromCRD builds a CRDDescription from annotations present in the CRD.
-func buildCRDDescriptionFromCRD(crd *unstructured.Unstructured) (*olmv1alpha1.CRDDescription, error) {
-	var (
-		ok  bool
-		err error
-	)
+// descriptorAnnotationPrefix prefixes the CRD annotations declaring the x-descriptors of a field, as
+// in "x-descriptors.service.binding/status.dbCredentials"; the annotation value is a YAML list of
+// x-descriptors.
+var descriptorAnnotationPrefix = "x-descriptors." + binding.AnnotationPrefix + "/"
 
-	crdDescription := &olmv1alpha1.CRDDescription{
-		Name: crd.GetName(),
-	}
-
-	crdDescription.Kind, ok, err = unstructured.NestedString(crd.Object, "spec", "names", "kind")
+// buildCRDDescriptionFromCRD builds a CRDDescription of the given version from annotations present
+// in the CRD; nil is returned when the CRD doesn't serve the version.
+func buildCRDDescriptionFromCRD(crd *unstructured.Unstructured, version string) (*olmv1alpha1.CRDDescription, error) {
+	kind, ok, err := unstructured.NestedString(crd.Object, "spec", "names", "kind")
 	if err != nil || !ok {
 		return nil, err
 	}
 
-	crdDescription.Version, ok, err = unstructured.NestedString(crd.Object, "spec", "version")
-	if err != nil || !ok {
+	served, err := crdServesVersion(crd, version)
+	if err != nil || !served {
 		return nil, err
 	}
 
-	specDescriptors, statusDescriptors, err := buildDescriptorsFromAnnotations(crd.GetAnnotations())
+	specDescriptors, statusDescriptors, err := buildDescriptorsFromAnnotations(crdAnnotations(crd, version))
 	if err != nil {
 		return nil, err
 	}
-	crdDescription.SpecDescriptors = append(crdDescription.SpecDescriptors, specDescriptors...)
-	crdDescription.StatusDescriptors = append(crdDescription.StatusDescriptors, statusDescriptors...)
 
-	return crdDescription, nil
+	return &olmv1alpha1.CRDDescription{
+		Name:              crd.GetName(),
+		Kind:              kind,
+		Version:           version,
+		SpecDescriptors:   specDescriptors,
+		StatusDescriptors: statusDescriptors,
+	}, nil
+}
+
+// crdServesVersion returns whether the CRD serves the given version, either listed in
+// "spec.versions" or, for v1beta1 CRDs, as "spec.version".
+func crdServesVersion(crd *unstructured.Unstructured, version string) (bool, error) {
+	versions, ok, err := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	if err != nil {
+		return false, err
+	}
+	if !ok || len(versions) == 0 {
+		v, _, err := unstructured.NestedString(crd.Object, "spec", "version")
+		return v == version, err
+	}
+	for _, v := range versions {
+		m, ok := v.(map[string]interface{})
+		if !ok || m["name"] != version {
+			continue
+		}
+		served, _ := m["served"].(bool)
+		return served, nil
+	}
+	return false, nil
 }
 
 // buildDescriptorsFromAnnotations builds two descriptors collection, one for spec descriptors and
-// another for status descriptors.
+// another for status descriptors, from the descriptor annotations.
 func buildDescriptorsFromAnnotations(in map[string]string) (
 	[]olmv1alpha1.SpecDescriptor,
 	[]olmv1alpha1.StatusDescriptor,
 	error,
 ) {
-	// TODO: check if this function is no-op (it looks like that)
 	var specDescriptors []olmv1alpha1.SpecDescriptor
 	var statusDescriptors []olmv1alpha1.StatusDescriptor
 
+	// accumulate the x-descriptors of each field path, e.g. "status.dbCredentials"
 	acc := make(map[string][]string)
+	for k, v := range in {
+		if !strings.HasPrefix(k, descriptorAnnotationPrefix) {
+			continue
+		}
+		fieldPath := strings.TrimPrefix(k, descriptorAnnotationPrefix)
+		var descriptors []string
+		if err := yaml.Unmarshal([]byte(v), &descriptors); err != nil {
+			return nil, nil, fmt.Errorf("annotation %q: %v", k, err)
+		}
+		acc[fieldPath] = append(acc[fieldPath], descriptors...)
+	}
 
-	// create the status and/or spec descriptors based on the
-	for fieldPath, descriptors := range acc {
-		sort.Strings(descriptors)
-		path := strings.Split(fieldPath, ".")
+	fieldPaths := make([]string, 0, len(acc))
+	for fieldPath := range acc {
+		fieldPaths = append(fieldPaths, fieldPath)
+	}
+	sort.Strings(fieldPaths)
+
+	// create the status and/or spec descriptors based on the root of the field path
+	for _, fieldPath := range fieldPaths {
+		descriptors := acc[fieldPath]
+		path := strings.SplitN(fieldPath, ".", 2)
+		if len(path) != 2 {
+			continue
+		}
 		if path[0] == "status" {
 			statusDescriptors = append(statusDescriptors, olmv1alpha1.StatusDescriptor{
 				Path:         path[1],
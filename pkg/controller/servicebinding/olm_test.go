@@ -109,3 +109,62 @@ func TestOLMNew(t *testing.T) {
 		assertGVKs(t, gvks)
 	})
 }
+
+func TestBuildCRDDescriptionFromCRD(t *testing.T) {
+	crd, err := mocks.UnstructuredDatabaseCRDV1Mock("")
+	require.NoError(t, err)
+
+	t.Run("served version", func(t *testing.T) {
+		crdDescription, err := buildCRDDescriptionFromCRD(crd, mocks.CRDVersion)
+		require.NoError(t, err)
+		require.Equal(t, &olmv1alpha1.CRDDescription{
+			Name:    crd.GetName(),
+			Kind:    mocks.CRDKind,
+			Version: mocks.CRDVersion,
+			StatusDescriptors: []olmv1alpha1.StatusDescriptor{{
+				Path: "dbCredentials",
+				XDescriptors: []string{
+					"urn:alm:descriptor:io.kubernetes:Secret",
+					"service.binding",
+				},
+			}},
+		}, crdDescription)
+	})
+
+	t.Run("version not served", func(t *testing.T) {
+		crdDescription, err := buildCRDDescriptionFromCRD(crd, "v1")
+		require.NoError(t, err)
+		require.Nil(t, crdDescription)
+	})
+
+	t.Run("v1beta1 CRD", func(t *testing.T) {
+		crd, err := mocks.UnstructuredDatabaseCRDMock("")
+		require.NoError(t, err)
+		crdDescription, err := buildCRDDescriptionFromCRD(crd, mocks.CRDVersion)
+		require.NoError(t, err)
+		require.NotNil(t, crdDescription)
+		require.Equal(t, mocks.CRDVersion, crdDescription.Version)
+		require.Empty(t, crdDescription.StatusDescriptors)
+	})
+}
+
+func TestBuildDescriptorsFromAnnotations(t *testing.T) {
+	specDescriptors, statusDescriptors, err := buildDescriptorsFromAnnotations(map[string]string{
+		"x-descriptors.service.binding/spec.dbName":                 `["service.binding"]`,
+		"x-descriptors.service.binding/status.data.dbConfiguration": "- urn:alm:descriptor:io.kubernetes:ConfigMap\n- service.binding",
+		"x-descriptors.service.binding/metadata.name":               `["service.binding"]`,
+		"service.binding/username":                                  "path={.status.username}",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []olmv1alpha1.SpecDescriptor{
+		{Path: "dbName", XDescriptors: []string{"service.binding"}},
+	}, specDescriptors)
+	require.Equal(t, []olmv1alpha1.StatusDescriptor{
+		{Path: "data.dbConfiguration", XDescriptors: []string{"urn:alm:descriptor:io.kubernetes:ConfigMap", "service.binding"}},
+	}, statusDescriptors)
+
+	_, _, err = buildDescriptorsFromAnnotations(map[string]string{
+		"x-descriptors.service.binding/spec.dbName": "service.binding: {",
+	})
+	require.Error(t, err)
+}
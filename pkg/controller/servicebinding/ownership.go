@@ -0,0 +1,123 @@
+package servicebinding
+
+import (
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+)
+
+const (
+	// defaultOwnershipDepth detects resources directly owned by a service.
+	defaultOwnershipDepth = 1
+	// ownershipAssociation is the association of resources owned, directly or not, by a service.
+	ownershipAssociation = "Ownership"
+	// labelsAssociation is the association of resources having a service's association labels.
+	labelsAssociation = "Labels"
+)
+
+// labelAssociationsSelector returns the selector matching all the given association labels, where
+// labels without value match the service name; nil when no associations are given.
+func labelAssociationsSelector(associations []v1alpha1.LabelAssociation, serviceName string) labels.Selector {
+	if len(associations) == 0 {
+		return nil
+	}
+	set := make(labels.Set, len(associations))
+	for _, a := range associations {
+		value := a.Value
+		if value == "" {
+			value = serviceName
+		}
+		set[a.Key] = value
+	}
+	return labels.SelectorFromSet(set)
+}
+
+// ownershipResolver walks up the owner references of resources in a namespace, fetching each owner
+// at most once.
+type ownershipResolver struct {
+	client     dynamic.Interface
+	restMapper meta.RESTMapper
+	ns         string
+	// owners holds the owner references of the owners already fetched, by UID.
+	owners map[types.UID][]metav1.OwnerReference
+}
+
+// newOwnershipResolver returns a resolver for resources in the given namespace.
+func newOwnershipResolver(client dynamic.Interface, restMapper meta.RESTMapper, ns string) *ownershipResolver {
+	return &ownershipResolver{
+		client:     client,
+		restMapper: restMapper,
+		ns:         ns,
+		owners:     make(map[types.UID][]metav1.OwnerReference),
+	}
+}
+
+// isOwnedBy returns whether a resource having the given owner references is owned by uid, following
+// at most depth owner references.
+func (r *ownershipResolver) isOwnedBy(refs []metav1.OwnerReference, uid types.UID, depth int) (bool, error) {
+	for _, ref := range refs {
+		if ref.UID == uid {
+			return true, nil
+		}
+	}
+	if depth <= 1 {
+		return false, nil
+	}
+	for _, ref := range refs {
+		ownerRefs, err := r.ownerReferences(ref)
+		if err != nil {
+			return false, err
+		}
+		if owned, err := r.isOwnedBy(ownerRefs, uid, depth-1); err != nil || owned {
+			return owned, err
+		}
+	}
+	return false, nil
+}
+
+// ownerReferences returns the owner references of the owner referred by ref; owners which are gone,
+// or of a kind unknown to the cluster, have none.
+func (r *ownershipResolver) ownerReferences(ref metav1.OwnerReference) ([]metav1.OwnerReference, error) {
+	if refs, ok := r.owners[ref.UID]; ok {
+		return refs, nil
+	}
+
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := r.restMapper.RESTMapping(gv.WithKind(ref.Kind).GroupKind(), gv.Version)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			r.owners[ref.UID] = nil
+			return nil, nil
+		}
+		return nil, err
+	}
+	ns := r.ns
+	if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+		ns = ""
+	}
+	owner, err := r.client.Resource(mapping.Resource).Namespace(ns).Get(ref.Name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			r.owners[ref.UID] = nil
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var refs []metav1.OwnerReference
+	// a different UID means the owner has been replaced by a namesake
+	if owner.GetUID() == ref.UID {
+		refs = owner.GetOwnerReferences()
+	}
+	r.owners[ref.UID] = refs
+	return refs, nil
+}
@@ -0,0 +1,154 @@
+package servicebinding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/log"
+	"github.com/redhat-developer/service-binding-operator/pkg/testutils"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+// newOwnedTestObject returns an object of the given kind owned by the given owners.
+func newOwnedTestObject(
+	gvk schema.GroupVersionKind,
+	ns string,
+	name string,
+	owners ...*unstructured.Unstructured,
+) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	u.SetGroupVersionKind(gvk)
+	u.SetNamespace(ns)
+	u.SetName(name)
+	u.SetUID(types.UID(ns + "-" + name))
+	refs := make([]metav1.OwnerReference, 0, len(owners))
+	for _, o := range owners {
+		refs = append(refs, metav1.OwnerReference{
+			APIVersion: o.GetAPIVersion(),
+			Kind:       o.GetKind(),
+			Name:       o.GetName(),
+			UID:        o.GetUID(),
+		})
+	}
+	u.SetOwnerReferences(refs)
+	return u
+}
+
+func TestLabelAssociationsSelector(t *testing.T) {
+	require.Nil(t, labelAssociationsSelector(nil, "db"))
+
+	selector := labelAssociationsSelector([]v1alpha1.LabelAssociation{
+		{Key: "app.kubernetes.io/instance"},
+		{Key: "app.kubernetes.io/part-of", Value: "postgres"},
+	}, "db")
+	require.Equal(t, "app.kubernetes.io/instance=db,app.kubernetes.io/part-of=postgres", selector.String())
+}
+
+func TestGetOwnedResourcesDetection(t *testing.T) {
+	ns := "detection"
+	databaseGVK := schema.GroupVersionKind{Group: "postgresql.baiju.dev", Version: "v1alpha1", Kind: "Database"}
+	deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	secretGVK := schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+	unknownGVK := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Unknown"}
+
+	db := newOwnedTestObject(databaseGVK, ns, "db")
+	// the service owns a workload which owns a Service
+	workload := newOwnedTestObject(deploymentGVK, ns, "db-workload", db)
+	workloadSvc := newOwnedTestObject(serviceGVK, ns, "db-svc", workload)
+	// a Service directly owned by the service, also labeled
+	directSvc := newOwnedTestObject(serviceGVK, ns, "db-direct", db)
+	directSvc.SetLabels(map[string]string{"app.kubernetes.io/instance": "db"})
+	// a Secret associated by labels only
+	labeledSecret := newOwnedTestObject(secretGVK, ns, "db-credentials")
+	labeledSecret.SetLabels(map[string]string{"app.kubernetes.io/instance": "db"})
+	// resources owned by unknown or missing owners are ignored
+	unknownOwned := newOwnedTestObject(serviceGVK, ns, "unknown-owned", newOwnedTestObject(unknownGVK, ns, "x"))
+	missingOwned := newOwnedTestObject(secretGVK, ns, "missing-owned", newOwnedTestObject(deploymentGVK, ns, "gone"))
+
+	f := mocks.NewFake(t, ns)
+	for _, obj := range []*unstructured.Unstructured{db, workload, workloadSvc, directSvc, labeledSecret, unknownOwned, missingOwned} {
+		f.AddMockResource(obj)
+	}
+	restMapper := testutils.BuildTestRESTMapper()
+	logger := log.NewLog("testGetOwnedResourcesDetection")
+
+	type detected struct {
+		name        string
+		association string
+	}
+	detect := func(client *fakedynamic.FakeDynamicClient, detection *v1alpha1.BindingResourcesDetection) []detected {
+		resources, err := getOwnedResources(logger, client, ns, db.GetName(), db.GetUID(), detection, restMapper)
+		require.NoError(t, err)
+		names := make([]detected, 0, len(resources))
+		for _, r := range resources {
+			names = append(names, detected{r.obj.GetName(), r.association})
+		}
+		return names
+	}
+	depth := func(d int32) *int32 { return &d }
+
+	t.Run("directly owned by default", func(t *testing.T) {
+		require.ElementsMatch(t, []detected{
+			{"db-direct", ownershipAssociation},
+		}, detect(f.FakeDynClient(), nil))
+	})
+
+	t.Run("owned within depth", func(t *testing.T) {
+		client := f.FakeDynClient()
+		require.ElementsMatch(t, []detected{
+			{"db-direct", ownershipAssociation},
+			{"db-svc", ownershipAssociation},
+		}, detect(client, &v1alpha1.BindingResourcesDetection{OwnershipDepth: depth(2)}))
+
+		// each owner is fetched once
+		gets := make(map[string]int)
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "get" {
+				gets[action.GetResource().Resource]++
+			}
+		}
+		require.Equal(t, map[string]int{"deployments": 2}, gets, "db-workload and the missing owner")
+	})
+
+	t.Run("owned or labeled", func(t *testing.T) {
+		require.ElementsMatch(t, []detected{
+			{"db-direct", ownershipAssociation},
+			{"db-svc", ownershipAssociation},
+			{"db-credentials", labelsAssociation},
+		}, detect(f.FakeDynClient(), &v1alpha1.BindingResourcesDetection{
+			OwnershipDepth:    depth(3),
+			LabelAssociations: []v1alpha1.LabelAssociation{{Key: "app.kubernetes.io/instance"}},
+		}))
+	})
+}
+
+func TestServiceContextListGetDetectedResources(t *testing.T) {
+	secret := v1alpha1.DetectedResource{
+		GroupVersionKind: metav1.GroupVersionKind{Version: "v1", Kind: "Secret"},
+		Association:      labelsAssociation,
+	}
+	secret.Name = "db-credentials"
+	svc := v1alpha1.DetectedResource{
+		GroupVersionKind: metav1.GroupVersionKind{Version: "v1", Kind: "Service"},
+		Association:      ownershipAssociation,
+	}
+	svc.Name = "db-svc"
+	empty := svc
+	empty.Name = "db-empty"
+
+	sc := serviceContextList{
+		{envVars: map[string]interface{}{"svc": "value"}},
+		{envVars: map[string]interface{}{"password": "secret"}, detectedResource: &secret},
+		{envVars: map[string]interface{}{"clusterIP": "10.0.0.1"}, detectedResource: &svc},
+		{envVars: map[string]interface{}{"ports": []interface{}{int64(5432)}}, detectedResource: &svc},
+		{detectedResource: &empty},
+	}
+	require.Equal(t, []v1alpha1.DetectedResource{secret, svc}, sc.getDetectedResources())
+}
@@ -0,0 +1,219 @@
+package servicebinding
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/log"
+)
+
+// queueController is a controller.Controller working off the queue returned by makeQueue; it
+// behaves as the controller-runtime controller, whose queue can't be configured, except that a
+// failed reconciliation doesn't pause its worker.
+type queueController struct {
+	name                    string
+	reconciler              reconcile.Reconciler
+	maxConcurrentReconciles int
+	makeQueue               func() workqueue.RateLimitingInterface
+	// setFields injects dependencies into sources, event handlers and predicates.
+	setFields func(i interface{}) error
+	// waitForCacheSync waits for the informer caches to be synced before starting workers.
+	waitForCacheSync func(stop <-chan struct{}) bool
+
+	// synced is set to 1 once the caches are synced and the workers started; it's read without
+	// holding mu, which is held while waiting for the caches.
+	synced int32
+
+	mu      sync.Mutex // guards the fields below
+	queue   workqueue.RateLimitingInterface
+	started bool
+	watches []watchDescription
+	logger  *log.Log
+}
+
+// watchDescription holds a watch to start once the controller is started.
+type watchDescription struct {
+	src        source.Source
+	handler    handler.EventHandler
+	predicates []predicate.Predicate
+}
+
+var _ controller.Controller = (*queueController)(nil)
+var _ inject.Injector = (*queueController)(nil)
+
+// newQueueController creates a queueController reconciling with the given options, and adds it to
+// the manager.
+func newQueueController(
+	name string,
+	mgr manager.Manager,
+	options controller.Options,
+	makeQueue func() workqueue.RateLimitingInterface,
+) (*queueController, error) {
+	if options.Reconciler == nil {
+		return nil, fmt.Errorf("must specify Reconciler")
+	}
+	if options.MaxConcurrentReconciles <= 0 {
+		options.MaxConcurrentReconciles = 1
+	}
+	if err := mgr.SetFields(options.Reconciler); err != nil {
+		return nil, err
+	}
+	c := &queueController{
+		name:                    name,
+		reconciler:              options.Reconciler,
+		maxConcurrentReconciles: options.MaxConcurrentReconciles,
+		makeQueue:               makeQueue,
+		setFields:               mgr.SetFields,
+		waitForCacheSync:        mgr.GetCache().WaitForCacheSync,
+		logger:                  log.NewLog("queuecontroller").WithValues("Controller", name),
+	}
+	return c, mgr.Add(c)
+}
+
+// InjectFunc implements inject.Injector.
+func (c *queueController) InjectFunc(f inject.Func) error {
+	c.setFields = f
+	return nil
+}
+
+// Reconcile implements reconcile.Reconciler.
+func (c *queueController) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	return c.reconciler.Reconcile(req)
+}
+
+// Watch implements controller.Controller; the source is started right away when the controller is
+// started already.
+func (c *queueController) Watch(src source.Source, h handler.EventHandler, prct ...predicate.Predicate) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.setFields(src); err != nil {
+		return err
+	}
+	if err := c.setFields(h); err != nil {
+		return err
+	}
+	for _, pr := range prct {
+		if err := c.setFields(pr); err != nil {
+			return err
+		}
+	}
+
+	// watches added once started are started right away, and aren't kept, so the sources stopped
+	// afterwards, such as dynamic informers, can be released
+	if c.started {
+		c.logger.Debug("Starting event source", "Source", src)
+		return src.Start(h, c.queue, prct...)
+	}
+	c.watches = append(c.watches, watchDescription{src: src, handler: h, predicates: prct})
+	return nil
+}
+
+// Start implements controller.Controller; it starts the watches, then the workers once the caches
+// are synced, and blocks until the given channel is closed.
+func (c *queueController) Start(stop <-chan struct{}) error {
+	c.mu.Lock()
+	c.queue = c.makeQueue()
+	defer c.queue.ShutDown()
+
+	err := func() error {
+		defer c.mu.Unlock()
+		defer utilruntime.HandleCrash()
+
+		for _, w := range c.watches {
+			c.logger.Debug("Starting event source", "Source", w.src)
+			if err := w.src.Start(w.handler, c.queue, w.predicates...); err != nil {
+				return err
+			}
+		}
+
+		if !c.waitForCacheSync(stop) {
+			return fmt.Errorf("failed to wait for %s caches to sync", c.name)
+		}
+
+		c.logger.Info("Starting workers", "WorkerCount", c.maxConcurrentReconciles)
+		for i := 0; i < c.maxConcurrentReconciles; i++ {
+			go wait.Until(c.worker, time.Second, stop)
+		}
+		c.started = true
+		atomic.StoreInt32(&c.synced, 1)
+		return nil
+	}()
+	if err != nil {
+		return err
+	}
+
+	<-stop
+	c.logger.Info("Stopping workers")
+	return nil
+}
+
+// hasStarted returns whether the caches are synced and the workers started.
+func (c *queueController) hasStarted() bool {
+	return atomic.LoadInt32(&c.synced) == 1
+}
+
+// worker processes items until the queue is shut down.
+func (c *queueController) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem reconciles the next item of the queue, and returns false once the queue is
+// shut down.
+func (c *queueController) processNextWorkItem() bool {
+	obj, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(obj)
+	c.reconcileHandler(obj)
+	return true
+}
+
+// reconcileHandler reconciles the given item, and queues it again as the result asks; failures are
+// retried after the rate limiter's delay.
+func (c *queueController) reconcileHandler(obj interface{}) {
+	req, ok := obj.(reconcile.Request)
+	if !ok {
+		// an invalid item would be retried forever
+		c.queue.Forget(obj)
+		c.logger.Error(nil, "Queue item was not a Request", "Type", fmt.Sprintf("%T", obj), "Value", obj)
+		return
+	}
+
+	start := time.Now()
+	result, err := c.reconciler.Reconcile(req)
+	res := reconcileResult(result, err)
+	reconcilesTotal.WithLabelValues(res).Inc()
+	controllerReconcileTotal.WithLabelValues(c.name, res).Inc()
+	controllerReconcileTime.WithLabelValues(c.name).Observe(time.Since(start).Seconds())
+	if err != nil {
+		controllerReconcileErrors.WithLabelValues(c.name).Inc()
+	}
+	switch {
+	case err != nil:
+		c.queue.AddRateLimited(req)
+		c.logger.Error(err, "Reconciler error", "Request", req, "Retries", c.queue.NumRequeues(req))
+	case result.RequeueAfter > 0:
+		c.queue.Forget(req)
+		c.queue.AddAfter(req, result.RequeueAfter)
+	case result.Requeue:
+		c.queue.AddRateLimited(req)
+	default:
+		c.queue.Forget(req)
+	}
+}
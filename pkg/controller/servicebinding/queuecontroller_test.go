@@ -0,0 +1,108 @@
+package servicebinding
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/config"
+	"github.com/redhat-developer/service-binding-operator/pkg/log"
+)
+
+// recordingReconciler records the requests it reconciles, failing the ones of the broken namespace.
+type recordingReconciler struct {
+	mu         sync.Mutex
+	reconciled []reconcile.Request
+}
+
+func (r *recordingReconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	r.mu.Lock()
+	r.reconciled = append(r.reconciled, req)
+	r.mu.Unlock()
+	if req.Namespace == "broken" {
+		return reconcile.Result{}, errors.New("broken")
+	}
+	return reconcile.Result{}, nil
+}
+
+// indexOf returns the position of the given request among the reconciled ones, or -1.
+func (r *recordingReconciler) indexOf(req reconcile.Request) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, reconciled := range r.reconciled {
+		if reconciled == req {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *recordingReconciler) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.reconciled)
+}
+
+func TestQueueControllerFairness(t *testing.T) {
+	const brokenCount = 50
+
+	rateLimiter := newBackoffRateLimiter()
+	rateLimiter.backoff = func() config.RetryBackoff {
+		return config.RetryBackoff{InitialDelay: time.Hour, MaxDelay: time.Hour}
+	}
+	queue := newFairQueue("", rateLimiter)
+	for i := 0; i < brokenCount; i++ {
+		queue.Add(request("broken", fmt.Sprintf("sbr-%d", i)))
+	}
+	healthy := request("healthy", "sbr")
+	queue.Add(healthy)
+
+	r := &recordingReconciler{}
+	c := &queueController{
+		name:                    "test",
+		reconciler:              r,
+		maxConcurrentReconciles: 1,
+		makeQueue:               func() workqueue.RateLimitingInterface { return queue },
+		setFields:               func(interface{}) error { return nil },
+		waitForCacheSync:        func(<-chan struct{}) bool { return true },
+		logger:                  log.NewLog("queuecontroller_test"),
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		require.NoError(t, c.Start(stop))
+	}()
+
+	// a failing reconciliation doesn't pause the worker, which would take about a second per
+	// failure
+	require.Eventually(t, func() bool {
+		return r.count() == brokenCount+1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 1, r.indexOf(healthy), "the healthy namespace takes its turn after the first broken ServiceBinding")
+
+	require.Equal(t, 1, queue.NumRequeues(request("broken", "sbr-0")), "failures are retried after a backoff")
+	require.Equal(t, 0, queue.NumRequeues(healthy))
+	require.Equal(t, 0, queue.Len())
+	require.Equal(t, float64(brokenCount), testutil.ToFloat64(controllerReconcileTotal.WithLabelValues("test", errorResult)))
+	require.Equal(t, float64(brokenCount), testutil.ToFloat64(controllerReconcileErrors.WithLabelValues("test")))
+	require.Equal(t, float64(1), testutil.ToFloat64(controllerReconcileTotal.WithLabelValues("test", successResult)))
+
+	started := 0
+	src := source.Func(func(handler.EventHandler, workqueue.RateLimitingInterface, ...predicate.Predicate) error {
+		started++
+		return nil
+	})
+	require.NoError(t, c.Watch(src, &handler.EnqueueRequestForObject{}))
+	require.Equal(t, 1, started, "watches added once started are started right away")
+	require.Empty(t, c.watches, "watches added once started aren't kept")
+}
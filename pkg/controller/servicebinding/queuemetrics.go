@@ -0,0 +1,207 @@
+package servicebinding
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// queueMetricsUpdatePeriod is how often the unfinished work metrics of a queue are updated.
+const queueMetricsUpdatePeriod = 500 * time.Millisecond
+
+// workqueueMetricsProvider is a workqueue.MetricsProvider exposing the metrics of the queues it's
+// given in the manager's registry, under the names controller-runtime uses for its own queues;
+// controller-runtime's provider is only available to the queues created by client-go.
+type workqueueMetricsProvider struct{}
+
+var _ workqueue.MetricsProvider = workqueueMetricsProvider{}
+
+// registerSharedMetric registers the given metric, and returns the metric already registered under
+// the same name and labels, such as controller-runtime's, if any.
+func registerSharedMetric(c prometheus.Collector) prometheus.Collector {
+	if err := metrics.Registry.Register(c); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			return are.ExistingCollector
+		}
+		reconcilerLog.Error(err, "Failed to register metric")
+	}
+	return c
+}
+
+// The metrics controller-runtime's controllers record for each reconciliation, recorded by
+// queueController as well.
+var (
+	controllerReconcileTotal = registerSharedMetric(prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "controller_runtime_reconcile_total",
+		Help: "Total number of reconciliations per controller",
+	}, []string{"controller", "result"})).(*prometheus.CounterVec)
+	controllerReconcileErrors = registerSharedMetric(prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "controller_runtime_reconcile_errors_total",
+		Help: "Total number of reconciliation errors per controller",
+	}, []string{"controller"})).(*prometheus.CounterVec)
+	controllerReconcileTime = registerSharedMetric(prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "controller_runtime_reconcile_time_seconds",
+		Help: "Length of time per reconciliation per controller",
+	}, []string{"controller"})).(*prometheus.HistogramVec)
+)
+
+func (workqueueMetricsProvider) NewDepthMetric(queue string) workqueue.GaugeMetric {
+	return registerSharedMetric(prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:        "workqueue_depth",
+		Help:        "Current depth of workqueue",
+		ConstLabels: prometheus.Labels{"name": queue},
+	})).(prometheus.Gauge)
+}
+
+func (workqueueMetricsProvider) NewAddsMetric(queue string) workqueue.CounterMetric {
+	return registerSharedMetric(prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "workqueue_adds_total",
+		Help:        "Total number of adds handled by workqueue",
+		ConstLabels: prometheus.Labels{"name": queue},
+	})).(prometheus.Counter)
+}
+
+func (workqueueMetricsProvider) NewLatencyMetric(queue string) workqueue.HistogramMetric {
+	return registerSharedMetric(prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:        "workqueue_queue_duration_seconds",
+		Help:        "How long in seconds an item stays in workqueue before being requested.",
+		ConstLabels: prometheus.Labels{"name": queue},
+		Buckets:     prometheus.ExponentialBuckets(10e-9, 10, 10),
+	})).(prometheus.Histogram)
+}
+
+func (workqueueMetricsProvider) NewWorkDurationMetric(queue string) workqueue.HistogramMetric {
+	return registerSharedMetric(prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:        "workqueue_work_duration_seconds",
+		Help:        "How long in seconds processing an item from workqueue takes.",
+		ConstLabels: prometheus.Labels{"name": queue},
+		Buckets:     prometheus.ExponentialBuckets(10e-9, 10, 10),
+	})).(prometheus.Histogram)
+}
+
+func (workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(queue string) workqueue.SettableGaugeMetric {
+	return registerSharedMetric(prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "workqueue_unfinished_work_seconds",
+		Help: "How many seconds of work has done that " +
+			"is in progress and hasn't been observed by work_duration. Large " +
+			"values indicate stuck threads. One can deduce the number of stuck " +
+			"threads by observing the rate at which this increases.",
+		ConstLabels: prometheus.Labels{"name": queue},
+	})).(prometheus.Gauge)
+}
+
+func (workqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(queue string) workqueue.SettableGaugeMetric {
+	return registerSharedMetric(prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "workqueue_longest_running_processor_seconds",
+		Help: "How many seconds has the longest running " +
+			"processor for workqueue been running.",
+		ConstLabels: prometheus.Labels{"name": queue},
+	})).(prometheus.Gauge)
+}
+
+func (workqueueMetricsProvider) NewRetriesMetric(queue string) workqueue.CounterMetric {
+	return registerSharedMetric(prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "workqueue_retries_total",
+		Help:        "Total number of retries handled by workqueue",
+		ConstLabels: prometheus.Labels{"name": queue},
+	})).(prometheus.Counter)
+}
+
+// queueMetrics tracks the metrics of a queue as client-go queues do; it isn't safe for concurrent
+// use. A nil queueMetrics ignores all operations.
+type queueMetrics struct {
+	depth                   workqueue.GaugeMetric
+	adds                    workqueue.CounterMetric
+	latency                 workqueue.HistogramMetric
+	workDuration            workqueue.HistogramMetric
+	unfinishedWorkSeconds   workqueue.SettableGaugeMetric
+	longestRunningProcessor workqueue.SettableGaugeMetric
+	retries                 workqueue.CounterMetric
+
+	addTimes             map[interface{}]time.Time
+	processingStartTimes map[interface{}]time.Time
+}
+
+// newQueueMetrics returns the metrics of the queue with the given name, created by the given
+// provider; queues without name have no metrics.
+func newQueueMetrics(name string, provider workqueue.MetricsProvider) *queueMetrics {
+	if name == "" {
+		return nil
+	}
+	return &queueMetrics{
+		depth:                   provider.NewDepthMetric(name),
+		adds:                    provider.NewAddsMetric(name),
+		latency:                 provider.NewLatencyMetric(name),
+		workDuration:            provider.NewWorkDurationMetric(name),
+		unfinishedWorkSeconds:   provider.NewUnfinishedWorkSecondsMetric(name),
+		longestRunningProcessor: provider.NewLongestRunningProcessorSecondsMetric(name),
+		retries:                 provider.NewRetriesMetric(name),
+		addTimes:                make(map[interface{}]time.Time),
+		processingStartTimes:    make(map[interface{}]time.Time),
+	}
+}
+
+// add records the given item has been added.
+func (m *queueMetrics) add(item interface{}) {
+	if m == nil {
+		return
+	}
+	m.adds.Inc()
+	m.depth.Inc()
+	if _, exists := m.addTimes[item]; !exists {
+		m.addTimes[item] = time.Now()
+	}
+}
+
+// get records the given item has been handed out.
+func (m *queueMetrics) get(item interface{}) {
+	if m == nil {
+		return
+	}
+	m.depth.Dec()
+	now := time.Now()
+	m.processingStartTimes[item] = now
+	if start, exists := m.addTimes[item]; exists {
+		m.latency.Observe(now.Sub(start).Seconds())
+		delete(m.addTimes, item)
+	}
+}
+
+// done records the given item has been processed.
+func (m *queueMetrics) done(item interface{}) {
+	if m == nil {
+		return
+	}
+	if start, exists := m.processingStartTimes[item]; exists {
+		m.workDuration.Observe(time.Since(start).Seconds())
+		delete(m.processingStartTimes, item)
+	}
+}
+
+// retry records an item has been added again after a failure.
+func (m *queueMetrics) retry() {
+	if m == nil {
+		return
+	}
+	m.retries.Inc()
+}
+
+// updateUnfinishedWork updates the metrics of the items being processed.
+func (m *queueMetrics) updateUnfinishedWork() {
+	if m == nil {
+		return
+	}
+	var total, oldest float64
+	now := time.Now()
+	for _, start := range m.processingStartTimes {
+		age := now.Sub(start).Seconds()
+		total += age
+		if age > oldest {
+			oldest = age
+		}
+	}
+	m.unfinishedWorkSeconds.Set(total)
+	m.longestRunningProcessor.Set(oldest)
+}
@@ -0,0 +1,69 @@
+package servicebinding
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/config"
+)
+
+// backoffRateLimiter is a workqueue.RateLimiter delaying the retries of each item exponentially,
+// from the initial delay of the operator configuration up to its maximum delay. A random jitter is
+// added to each delay, so items failing together aren't retried together. The configuration is read
+// on each retry, so changes are applied without a restart.
+type backoffRateLimiter struct {
+	mu       sync.Mutex
+	failures map[interface{}]int
+	// backoff returns the backoff configuration.
+	backoff func() config.RetryBackoff
+	// random returns a random number in [0.0,1.0).
+	random func() float64
+}
+
+var _ workqueue.RateLimiter = (*backoffRateLimiter)(nil)
+
+// newBackoffRateLimiter returns a backoffRateLimiter following the operator configuration.
+func newBackoffRateLimiter() *backoffRateLimiter {
+	return &backoffRateLimiter{
+		failures: make(map[interface{}]int),
+		backoff: func() config.RetryBackoff {
+			return config.Get().RetryBackoff
+		},
+		random: rand.Float64,
+	}
+}
+
+// When returns the delay before retrying the given item, and records one more failure for it.
+func (r *backoffRateLimiter) When(item interface{}) time.Duration {
+	r.mu.Lock()
+	failures := r.failures[item]
+	r.failures[item] = failures + 1
+	random := r.random()
+	r.mu.Unlock()
+
+	backoff := r.backoff()
+	delay := float64(backoff.InitialDelay) * math.Pow(2, float64(failures))
+	if delay > float64(backoff.MaxDelay) {
+		delay = float64(backoff.MaxDelay)
+	}
+	delay += delay * float64(backoff.JitterPercent) / 100 * random
+	return time.Duration(delay)
+}
+
+// Forget forgets the failures of the given item.
+func (r *backoffRateLimiter) Forget(item interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.failures, item)
+}
+
+// NumRequeues returns the number of failures of the given item.
+func (r *backoffRateLimiter) NumRequeues(item interface{}) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.failures[item]
+}
@@ -0,0 +1,56 @@
+package servicebinding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/config"
+)
+
+func TestBackoffRateLimiter(t *testing.T) {
+	backoff := config.RetryBackoff{InitialDelay: time.Second, MaxDelay: 5 * time.Second}
+	random := 0.0
+	r := newBackoffRateLimiter()
+	r.backoff = func() config.RetryBackoff { return backoff }
+	r.random = func() float64 { return random }
+
+	t.Run("exponential delay capped to the max delay", func(t *testing.T) {
+		defer r.Forget("item")
+		var delays []time.Duration
+		for i := 0; i < 5; i++ {
+			delays = append(delays, r.When("item"))
+		}
+		require.Equal(t, []time.Duration{
+			time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second,
+		}, delays)
+		require.Equal(t, 5, r.NumRequeues("item"))
+		require.Equal(t, 0, r.NumRequeues("other"), "items are backed off independently")
+	})
+
+	t.Run("jitter bounded by the jitter percent", func(t *testing.T) {
+		defer r.Forget("item")
+		backoff.JitterPercent = 20
+		random = 0.5
+		require.Equal(t, 1100*time.Millisecond, r.When("item"))
+		random = 0.999
+		require.True(t, r.When("item") < 2400*time.Millisecond)
+	})
+
+	t.Run("forget resets the delay", func(t *testing.T) {
+		defer r.Forget("item")
+		backoff.JitterPercent = 0
+		r.When("item")
+		r.When("item")
+		r.Forget("item")
+		require.Equal(t, 0, r.NumRequeues("item"))
+		require.Equal(t, time.Second, r.When("item"))
+	})
+
+	t.Run("configuration changes apply to the next retry", func(t *testing.T) {
+		defer r.Forget("item")
+		backoff.InitialDelay = 100 * time.Millisecond
+		require.Equal(t, 100*time.Millisecond, r.When("item"))
+	})
+}
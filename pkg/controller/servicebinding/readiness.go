@@ -0,0 +1,144 @@
+package servicebinding
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	// readyConditionAnnotation declares, on a service CRD or the service itself, the type of the
+	// status condition which must be true before the service is bound.
+	readyConditionAnnotation = "service-binding-operator.operators.coreos.com/ready-condition"
+	// readyPathAnnotation declares, on a service CRD or the service itself, the JSONPath expression
+	// which must find a value before the service is bound.
+	readyPathAnnotation = "service-binding-operator.operators.coreos.com/ready-path"
+)
+
+// readinessGate describes when a service is ready to be bound; a service without readiness gate is
+// always ready.
+type readinessGate struct {
+	// condition is the type of the status condition which must be true.
+	condition string
+	// path is the JSONPath expression, enclosed in curly braces, which must find a value which isn't
+	// empty, false or "False".
+	path string
+}
+
+// newReadinessGate returns the readiness gate declared by the given service selector fields.
+func newReadinessGate(condition *string, path *string) readinessGate {
+	return readinessGate{
+		condition: stringValueOrDefault(condition, ""),
+		path:      stringValueOrDefault(path, ""),
+	}
+}
+
+// withAnnotations returns the gate with its unset fields taken from the given service annotations.
+func (g readinessGate) withAnnotations(anns map[string]string) readinessGate {
+	if g.condition == "" {
+		g.condition = anns[readyConditionAnnotation]
+	}
+	if g.path == "" {
+		g.path = anns[readyPathAnnotation]
+	}
+	return g
+}
+
+// errServiceNotReady is returned when a service doesn't pass its readiness gate yet.
+type errServiceNotReady struct {
+	kind   string
+	name   string
+	reason string
+}
+
+func (e *errServiceNotReady) Error() string {
+	return fmt.Sprintf("%s %q is not ready: %s", e.kind, e.name, e.reason)
+}
+
+// isErrServiceNotReady returns whether the given error is an errServiceNotReady.
+func isErrServiceNotReady(err error) bool {
+	_, ok := err.(*errServiceNotReady)
+	return ok
+}
+
+// check returns an errServiceNotReady when the given service doesn't pass the gate, and an error
+// when the gate's path is invalid.
+func (g readinessGate) check(obj *unstructured.Unstructured) error {
+	notReady := func(format string, args ...interface{}) error {
+		return &errServiceNotReady{kind: obj.GetKind(), name: obj.GetName(), reason: fmt.Sprintf(format, args...)}
+	}
+
+	if g.condition != "" {
+		status, found := conditionStatus(obj, g.condition)
+		if !found {
+			return notReady("condition %s is not reported", g.condition)
+		}
+		if status != "True" {
+			return notReady("condition %s is %s", g.condition, status)
+		}
+	}
+
+	if g.path != "" {
+		if !strings.HasPrefix(g.path, "{") || !strings.HasSuffix(g.path, "}") {
+			return fmt.Errorf("ready path has invalid syntax: %q", g.path)
+		}
+		j := jsonpath.New("ready").AllowMissingKeys(true)
+		if err := j.Parse(g.path); err != nil {
+			return fmt.Errorf("ready path has invalid syntax: %q: %s", g.path, err)
+		}
+		results, err := j.FindResults(obj.Object)
+		if err != nil {
+			return err
+		}
+		for _, r := range results {
+			for _, v := range r {
+				if v.IsValid() && v.CanInterface() && isReadyValue(v.Interface()) {
+					return nil
+				}
+			}
+		}
+		return notReady("%s has no ready value", g.path)
+	}
+
+	return nil
+}
+
+// conditionStatus returns the status of the condition of the given type found in the service's
+// status.
+func conditionStatus(obj *unstructured.Unstructured, conditionType string) (string, bool) {
+	conditions, found, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil || !found {
+		return "", false
+	}
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != conditionType {
+			continue
+		}
+		status, _ := condition["status"].(string)
+		return status, true
+	}
+	return "", false
+}
+
+// isReadyValue returns whether the given value, found by a ready path, tells the service is ready:
+// any value but nil, false, the "false" string in any case, and empty strings, slices and maps.
+func isReadyValue(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return t
+	case string:
+		return t != "" && !strings.EqualFold(t, "false")
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() > 0
+	}
+	return true
+}
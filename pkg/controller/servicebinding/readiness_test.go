@@ -0,0 +1,70 @@
+package servicebinding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestReadinessGateCheck(t *testing.T) {
+	service := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":     "Database",
+		"metadata": map[string]interface{}{"name": "db"},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+				map[string]interface{}{"type": "Provisioned", "status": "False"},
+			},
+			"credentials": map[string]interface{}{"password": "", "user": "postgres"},
+			"phase":       "Running",
+		},
+	}}
+
+	tests := []struct {
+		name     string
+		gate     readinessGate
+		notReady string
+		invalid  bool
+	}{
+		{name: "no gate", gate: readinessGate{}},
+		{name: "true condition", gate: readinessGate{condition: "Ready"}},
+		{name: "false condition", gate: readinessGate{condition: "Provisioned"}, notReady: "condition Provisioned is False"},
+		{name: "missing condition", gate: readinessGate{condition: "Bound"}, notReady: "condition Bound is not reported"},
+		{name: "path found", gate: readinessGate{path: "{.status.credentials.user}"}},
+		{name: "empty value", gate: readinessGate{path: "{.status.credentials.password}"}, notReady: "has no ready value"},
+		{name: "missing value", gate: readinessGate{path: "{.status.host}"}, notReady: "has no ready value"},
+		{name: "filter", gate: readinessGate{path: `{.status.conditions[?(@.type=="Ready")].status}`}},
+		{name: "false filter", gate: readinessGate{path: `{.status.conditions[?(@.type=="Provisioned")].status}`}, notReady: "has no ready value"},
+		{name: "both", gate: readinessGate{condition: "Ready", path: "{.status.phase}"}},
+		{name: "invalid path", gate: readinessGate{path: ".status.phase"}, invalid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.gate.check(service)
+			switch {
+			case tt.invalid:
+				require.Error(t, err)
+				require.False(t, isErrServiceNotReady(err))
+			case tt.notReady != "":
+				require.True(t, isErrServiceNotReady(err))
+				require.Contains(t, err.Error(), `Database "db" is not ready: `)
+				require.Contains(t, err.Error(), tt.notReady)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReadinessGateWithAnnotations(t *testing.T) {
+	condition := "Ready"
+	anns := map[string]string{
+		readyConditionAnnotation: "Provisioned",
+		readyPathAnnotation:      "{.status.phase}",
+	}
+	require.Equal(t,
+		readinessGate{condition: "Ready", path: "{.status.phase}"},
+		newReadinessGate(&condition, nil).withAnnotations(anns),
+		"the service selector overrides the annotations")
+}
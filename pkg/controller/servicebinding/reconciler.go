@@ -3,6 +3,9 @@ package servicebinding
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -13,11 +16,14 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/controller/servicebinding/binding"
 	"github.com/redhat-developer/service-binding-operator/pkg/converter"
 	"github.com/redhat-developer/service-binding-operator/pkg/log"
+	"github.com/redhat-developer/service-binding-operator/pkg/tracing"
 )
 
 const (
@@ -28,6 +34,9 @@ const (
 	// InjectionReady indicates readiness to change application manifests to use those intermediate manifests
 	// If status is true, it indicates that the binding succeeded
 	InjectionReady conditionsv1.ConditionType = "InjectionReady"
+	// Degraded indicates, when true, that the binding proceeded without some of its optional
+	// services
+	Degraded conditionsv1.ConditionType = "Degraded"
 	// EmptyServiceSelectorsReason is used when the ServiceBinding has empty
 	// services.
 	EmptyServiceSelectorsReason = "EmptyServiceSelectors"
@@ -38,14 +47,31 @@ const (
 	ApplicationNotFoundReason = "ApplicationNotFound"
 	// ServiceNotFoundReason is used when the service is not found.
 	ServiceNotFoundReason = "ServiceNotFound"
+	// ServiceNotReadyReason is used when a service doesn't pass its readiness gate yet.
+	ServiceNotReadyReason = "ServiceNotReady"
+	// OptionalServiceSkippedReason is used when optional services can't be resolved, and are
+	// skipped.
+	OptionalServiceSkippedReason = "OptionalServiceSkipped"
+	// RequiredValueMissingReason is used when the value of a binding annotation marked as required
+	// can't be found.
+	RequiredValueMissingReason = "RequiredValueMissing"
+	// ExtraFieldsModifierFailedReason is used when one of the extra fields modifiers registered for
+	// the application fails.
+	ExtraFieldsModifierFailedReason = "ExtraFieldsModifierFailed"
 )
 
 // Reconciler reconciles a ServiceBinding object
 type reconciler struct {
-	dynClient       dynamic.Interface // kubernetes dynamic api client
-	scheme          *runtime.Scheme   // api scheme
-	restMapper      meta.RESTMapper   // restMapper to convert GVK and GVR
-	resourceWatcher ResourceWatcher   // ResourceWatcher to add watching for specific GVK/GVR
+	dynClient       dynamic.Interface    // kubernetes dynamic api client
+	apiClient       dynamic.Interface    // dynClient bypassing the informer cache, set while reconciling
+	scheme          *runtime.Scheme      // api scheme
+	restMapper      meta.RESTMapper      // restMapper to convert GVK and GVR
+	resourceWatcher ResourceWatcher      // ResourceWatcher to add watching for specific GVK/GVR
+	dependencies    *dependencyTracker   // objects referenced by each ServiceBinding
+	pending         *pendingKindTracker  // ServiceBindings waiting for kinds to be served
+	recorder        record.EventRecorder // events recorder, events are ignored when nil
+	shard           shard                // namespaces reconciled, all of them when nil
+	outcomes        *outcomeTracker      // outcome of the last reconciliation of each ServiceBinding
 }
 
 // reconcilerLog local logger instance
@@ -77,10 +103,6 @@ func (r *reconciler) getServiceBinding(
 	if err != nil {
 		return nil, err
 	}
-	if sbr.Spec.DetectBindingResources == nil {
-		falseBool := false
-		sbr.Spec.DetectBindingResources = &falseBool
-	}
 	return sbr, nil
 }
 
@@ -95,11 +117,37 @@ func (r *reconciler) getServiceBinding(
 // 4. Search applications that are interested to bind with given service, by inspecting labels. The
 //    Deployment (and other kinds) will be updated in "spec" level.
 func (r *reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx, span := tracing.StartSpan(context.Background(), "Reconcile",
+		serviceBindingNamespaceKey.String(request.Namespace),
+		serviceBindingNameKey.String(request.Name),
+	)
+
+	// API calls made while reconciling are traced as children of the reconcile span
+	traced := *r
+	traced.dynClient = tracing.NewDynamicClient(ctx, r.dynClient)
+	traced.apiClient = tracing.NewDynamicClient(ctx, uncached(r.dynClient))
+
+	res, err := traced.reconcile(ctx, request)
+	span.SetAttributes(
+		requeueKey.Bool(res.Requeue || res.RequeueAfter > 0),
+	)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+// reconcile executes the reconciliation steps described in Reconcile.
+func (r *reconciler) reconcile(ctx context.Context, request reconcile.Request) (res reconcile.Result, err error) {
 	logger := reconcilerLog.WithValues(
 		"Request.Namespace", request.Namespace,
 		"Request.Name", request.Name,
 	)
 
+	// requests queued before the namespace moved to another replica are left to that replica
+	if !ownsNamespace(r.shard, request.Namespace) {
+		logger.Debug("ServiceBinding namespace is owned by another operator replica, skipping")
+		return done()
+	}
+
 	logger.Info("Reconciling ServiceBinding...")
 
 	// fetch and validate namespaced ServiceBinding instance
@@ -109,6 +157,13 @@ func (r *reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 			logger.Info("SBR deleted after application deletion")
 			return done()
 		}
+		if k8serrors.IsNotFound(err) {
+			r.dependencies.forget(request.NamespacedName)
+			r.pending.forget(request.NamespacedName)
+			r.resourceWatcher.ReleaseWatchesFor(request.NamespacedName)
+			bindingConditions.forget(request.NamespacedName)
+			r.outcomes.forget(request.NamespacedName)
+		}
 		logger.Error(err, "On retrieving service-binding instance.")
 		return doneOnNotFound(err)
 	}
@@ -124,9 +179,14 @@ func (r *reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 	logger = logger.WithValues("ServiceBinding.Name", sbr.Name)
 	logger.Debug("Found service binding request to inspect")
 
-	ctx := context.Background()
+	// the outcome is listed by the debug endpoints
+	defer func() {
+		r.outcomes.record(request.NamespacedName, res, err)
+	}()
 
 	if len(sbr.Spec.Services) == 0 {
+		recordEvent(r.recorder, sbr, corev1.EventTypeWarning, EmptyServiceSelectorsReason,
+			errEmptyServices.Error())
 		_, updateErr := updateServiceBindingStatus(
 			r.dynClient,
 			sbr,
@@ -156,17 +216,85 @@ func (r *reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		return requeueError(errEmptyServices)
 	}
 
-	serviceCtxs, err := buildServiceContexts(
+	collectionStart := time.Now()
+	// the stage is observed once, when it completes or the reconciliation bails out of it
+	collectionObserved := false
+	observeCollection := func() {
+		if !collectionObserved {
+			collectionObserved = true
+			observeStageDuration(serviceCollectionStage, collectionStart)
+		}
+	}
+	defer observeCollection()
+	collectionCtx, collectionSpan := tracing.StartSpan(ctx, serviceCollectionStage,
+		servicesKey.StringSlice(serviceGVKs(sbr.Spec.Services)),
+	)
+	// objects read while collecting the services are tracked, so changes on them trigger a new
+	// reconciliation
+	collectionClient := newDependencyRecorder(
+		tracing.NewDynamicClient(collectionCtx, r.dynClient), r.restMapper)
+
+	// unset settings are taken from the namespace defaults, then from the operator configuration
+	defaults, err := getServiceBindingDefaults(collectionClient, sbr.GetNamespace())
+	if err != nil {
+		tracing.EndSpan(collectionSpan, err)
+		logger.Error(err, "On retrieving the namespace defaults")
+		return requeueError(err)
+	}
+	applyDefaults(sbr, defaults)
+
+	serviceCtxs, resolutions, err := buildServiceContexts(
 		logger.WithName("buildServiceContexts"),
-		r.dynClient,
+		collectionClient,
 		sbr.GetNamespace(),
 		sbr.Spec.Services,
 		sbr.Spec.DetectBindingResources,
+		sbr.Spec.BindingResourcesDetection,
 		r.restMapper,
 	)
+	// the state of each service is reported, whether the binding proceeds or not
+	sbr.Status.Services = resolutions.statuses()
+	for _, resolution := range resolutions {
+		// services of a kind not served yet, optional ones included, are reconciled again once
+		// their CRD is installed
+		if resolution.err != nil {
+			r.pending.waitFor(request.NamespacedName, resolution.err)
+		}
+	}
 	if err != nil {
+		tracing.EndSpan(collectionSpan, err)
+		r.trackReferences(logger, sbr, collectionClient.references())
+		// services of a kind not served yet are reconciled again once their CRD is installed
+		kindMissing := r.pending.waitFor(request.NamespacedName, err)
+		// services which aren't ready are tracked, and reconciled again once they change
+		if isErrServiceNotReady(err) {
+			logger.Info("Service is not ready", "Error", err.Error())
+			recordEvent(r.recorder, sbr, corev1.EventTypeNormal, ServiceNotReadyReason, err.Error())
+			updateErr := updateSBRConditions(r.dynClient, sbr,
+				conditionsv1.Condition{
+					Type:    CollectionReady,
+					Status:  corev1.ConditionFalse,
+					Reason:  ServiceNotReadyReason,
+					Message: err.Error(),
+				},
+				conditionsv1.Condition{
+					Type:   InjectionReady,
+					Status: corev1.ConditionFalse,
+				},
+				conditionsv1.Condition{
+					Type:   BindingReady,
+					Status: corev1.ConditionFalse,
+				},
+			)
+			if updateErr != nil {
+				logger.Error(updateErr, "Failed to update SBR conditions", "sbr", sbr)
+				return requeueError(updateErr)
+			}
+			return done()
+		}
 		//handle service not found error
-		if k8serrors.IsNotFound(err) {
+		if k8serrors.IsNotFound(err) || kindMissing {
+			recordEvent(r.recorder, sbr, corev1.EventTypeWarning, ServiceNotFoundReason, err.Error())
 			err = updateSBRConditions(r.dynClient, sbr,
 				conditionsv1.Condition{
 					Type:    CollectionReady,
@@ -186,27 +314,76 @@ func (r *reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 			if err != nil {
 				logger.Error(err, "Failed to update SBR conditions", "sbr", sbr)
 			}
+			if kindMissing && err == nil {
+				return done()
+			}
 		}
 		return requeueError(err)
 	}
+
+	r.trackReferences(logger, sbr,
+		append(collectionClient.references(), serviceCtxs.getReferences()...))
+
+	for _, annErr := range serviceCtxs.getAnnotationErrors() {
+		recordEvent(r.recorder, sbr, corev1.EventTypeWarning, AnnotationErrorReason,
+			"Annotation %q of %s %q: %s", annErr.Key, annErr.Kind, annErr.Name, annErr.Message)
+	}
+
+	if missing := serviceCtxs.getMissingRequired(); len(missing) > 0 {
+		err := errRequiredValueMissing(missing)
+		tracing.EndSpan(collectionSpan, err)
+		logger.Info("Required binding values are missing", "Error", err.Error())
+		recordEvent(r.recorder, sbr, corev1.EventTypeWarning, RequiredValueMissingReason, err.Error())
+		sbr.Status.AnnotationErrors = serviceCtxs.getAnnotationErrors()
+		updateErr := updateSBRConditions(r.dynClient, sbr,
+			conditionsv1.Condition{
+				Type:    CollectionReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  RequiredValueMissingReason,
+				Message: err.Error(),
+			},
+			conditionsv1.Condition{
+				Type:   InjectionReady,
+				Status: corev1.ConditionFalse,
+			},
+			conditionsv1.Condition{
+				Type:   BindingReady,
+				Status: corev1.ConditionFalse,
+			},
+		)
+		if updateErr != nil {
+			logger.Error(updateErr, "Failed to update SBR conditions", "sbr", sbr)
+			return requeueError(updateErr)
+		}
+		// the service and the objects it references are tracked, so the binding is reconciled
+		// again once the missing values show up
+		return done()
+	}
+
+	setDegradedCondition(r.recorder, sbr, resolutions)
+
 	binding, err := buildBinding(
-		r.dynClient,
+		collectionClient,
 		sbr.Spec.CustomEnvVar,
 		serviceCtxs,
 		sbr.Spec.EnvVarPrefix,
 	)
+	observeCollection()
+	tracing.EndSpan(collectionSpan, err)
 	if err != nil {
 		return requeueError(err)
 	}
 
 	options := &serviceBinderOptions{
 		dynClient:              r.dynClient,
+		secretClient:           r.apiClient,
 		detectBindingResources: *sbr.Spec.DetectBindingResources,
 		sbr:                    sbr,
 		logger:                 logger,
 		objects:                serviceCtxs.getServices(),
 		binding:                binding,
 		restMapper:             r.restMapper,
+		recorder:               r.recorder,
 	}
 
 	sb, err := buildServiceBinder(ctx, options)
@@ -217,20 +394,6 @@ func (r *reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		return noRequeue(err)
 	}
 
-	if sbr.Spec.Application != nil {
-		gvrSpec := sbr.Spec.Application.GroupVersionResource
-		gvr := schema.GroupVersionResource{
-			Group:    gvrSpec.Group,
-			Version:  gvrSpec.Version,
-			Resource: gvrSpec.Resource,
-		}
-
-		err = r.resourceWatcher.AddWatchForGVR(gvr)
-		if err != nil {
-			logger.Error(err, "Error add watching application GVR")
-		}
-	}
-
 	if sbr.GetDeletionTimestamp() != nil && sbr.GetOwnerReferences() != nil {
 		logger := logger.WithName("Deleting SBR when it has ownerReference")
 		logger.Debug("Removing resource finalizers...")
@@ -250,6 +413,83 @@ func (r *reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 	return sb.bind()
 }
 
+// trackReferences records the objects referenced by the ServiceBinding's services, and watches
+// their kinds along with the application's, so changes on those objects trigger a new
+// reconciliation. Kinds no longer referenced by the ServiceBinding stop being watched on its behalf.
+func (r *reconciler) trackReferences(
+	logger *log.Log,
+	sbr *v1alpha1.ServiceBinding,
+	refs []binding.ObjectReference,
+) {
+	namespacedName := types.NamespacedName{Namespace: sbr.GetNamespace(), Name: sbr.GetName()}
+	r.dependencies.track(namespacedName, refs)
+
+	gvks := make([]schema.GroupVersionKind, 0, len(refs)+1)
+	for _, ref := range refs {
+		gvks = append(gvks, ref.GroupVersionKind)
+	}
+
+	if sbr.Spec.Application != nil {
+		gvrSpec := sbr.Spec.Application.GroupVersionResource
+		gvr := schema.GroupVersionResource{
+			Group:    gvrSpec.Group,
+			Version:  gvrSpec.Version,
+			Resource: gvrSpec.Resource,
+		}
+		gvk, err := r.restMapper.KindFor(gvr)
+		if err != nil {
+			// applications of a resource not served yet are watched once their CRD is installed
+			r.pending.waitFor(namespacedName, err)
+			logger.Error(err, "Error add watching application GVR")
+		} else {
+			gvks = append(gvks, gvk)
+		}
+	}
+
+	if err := r.resourceWatcher.SetWatchesFor(namespacedName, gvks); err != nil {
+		logger.Error(err, "Error setting watches for referenced objects GVKs")
+	}
+}
+
+// setDegradedCondition sets the Degraded condition of a ServiceBinding declaring optional services,
+// true while some of them are skipped.
+func setDegradedCondition(
+	recorder record.EventRecorder,
+	sbr *v1alpha1.ServiceBinding,
+	resolutions serviceResolutionList,
+) {
+	hasOptional := false
+	for _, resolution := range resolutions {
+		hasOptional = hasOptional || resolution.optional()
+	}
+	if !hasOptional {
+		conditionsv1.RemoveStatusCondition(&sbr.Status.Conditions, Degraded)
+		return
+	}
+
+	skipped := resolutions.skipped()
+	if len(skipped) == 0 {
+		conditionsv1.SetStatusCondition(&sbr.Status.Conditions, conditionsv1.Condition{
+			Type:   Degraded,
+			Status: corev1.ConditionFalse,
+		})
+		return
+	}
+	msgs := make([]string, 0, len(skipped))
+	for _, resolution := range skipped {
+		msgs = append(msgs, fmt.Sprintf("%s %q: %s",
+			resolution.selector.Kind, resolution.selector.Name, resolution.err))
+	}
+	message := "optional services skipped: " + strings.Join(msgs, "; ")
+	recordEvent(recorder, sbr, corev1.EventTypeWarning, OptionalServiceSkippedReason, message)
+	conditionsv1.SetStatusCondition(&sbr.Status.Conditions, conditionsv1.Condition{
+		Type:    Degraded,
+		Status:  corev1.ConditionTrue,
+		Reason:  OptionalServiceSkippedReason,
+		Message: message,
+	})
+}
+
 func updateSBRConditions(dynClient dynamic.Interface, sbr *v1alpha1.ServiceBinding, conditions ...conditionsv1.Condition) error {
 	for _, v := range conditions {
 		conditionsv1.SetStatusCondition(&sbr.Status.Conditions, v)
@@ -263,7 +503,10 @@ func updateSBRConditions(dynClient dynamic.Interface, sbr *v1alpha1.ServiceBindi
 		Resource(groupVersion).
 		Namespace(sbr.GetNamespace())
 
-	_, err = nsClient.UpdateStatus(u, metav1.UpdateOptions{})
+	if _, err = nsClient.UpdateStatus(u, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	bindingConditions.observe(sbr)
 
-	return err
+	return nil
 }
@@ -1,6 +1,7 @@
 package servicebinding
 
 import (
+	"encoding/base64"
 	"reflect"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,10 +19,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 
 	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	pgapis "github.com/operator-backing-service-samples/postgresql-operator/pkg/apis"
 
 	"github.com/redhat-developer/service-binding-operator/test/mocks"
 )
@@ -78,6 +82,7 @@ func requireConditionPresentAndFalse(t *testing.T, condition conditionsv1.Condit
 
 type fakeResourceWatcher struct {
 	record map[schema.GroupVersionKind]bool
+	owned  map[types.NamespacedName][]schema.GroupVersionKind
 	mapper meta.RESTMapper
 }
 
@@ -97,9 +102,22 @@ func (f *fakeResourceWatcher) AddWatchForGVK(gvk schema.GroupVersionKind) error
 	return nil
 }
 
+func (f *fakeResourceWatcher) SetWatchesFor(sbr types.NamespacedName, gvks []schema.GroupVersionKind) error {
+	for _, gvk := range gvks {
+		f.record[gvk] = true
+	}
+	f.owned[sbr] = gvks
+	return nil
+}
+
+func (f *fakeResourceWatcher) ReleaseWatchesFor(sbr types.NamespacedName) {
+	delete(f.owned, sbr)
+}
+
 func newFakeResourceWatcher(mapper meta.RESTMapper) *fakeResourceWatcher {
 	return &fakeResourceWatcher{
 		record: make(map[schema.GroupVersionKind]bool),
+		owned:  make(map[types.NamespacedName][]schema.GroupVersionKind),
 		mapper: mapper,
 	}
 }
@@ -216,6 +234,118 @@ func TestReconcilerReconcileUsingSecret(t *testing.T) {
 	})
 }
 
+func TestReconcilerTracksDependencies(t *testing.T) {
+	backingServiceResourceRef := "test-dependencies"
+	f := mocks.NewFake(t, reconcilerNs)
+	f.AddMockedUnstructuredServiceBinding(reconcilerName, backingServiceResourceRef, reconcilerName, deploymentsGVR, nil)
+	f.AddMockedUnstructuredDatabaseCRD()
+	f.AddMockedUnstructuredDeployment(reconcilerName, nil)
+	f.AddMockedUnstructuredSecret("db-credentials")
+
+	mapper := buildCachedClientTestRESTMapper()
+	watcher := newFakeResourceWatcher(mapper)
+	r := &reconciler{dynClient: f.FakeDynClient(), restMapper: mapper, scheme: f.S, dependencies: newDependencyTracker()}
+	r.resourceWatcher = watcher
+	namespacedName := types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName}
+	databaseGVK := schema.GroupVersionKind{Group: mocks.CRDName, Version: mocks.CRDVersion, Kind: mocks.CRDKind}
+
+	// the service isn't found, and is tracked until it's created
+	res, _ := r.Reconcile(reconcileRequest())
+	require.True(t, res.Requeue)
+	require.Equal(t, []types.NamespacedName{namespacedName},
+		r.dependencies.dependentsOf(databaseGVK, reconcilerNs, backingServiceResourceRef))
+	require.True(t, watcher.record[databaseGVK], "the service kind is watched")
+
+	f.AddMockedUnstructuredDatabaseCR(backingServiceResourceRef)
+	r.dynClient = f.FakeDynClient()
+	res, err := r.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.False(t, res.Requeue)
+
+	// the service, its CRD, and the credentials Secret its CRD annotations point to are tracked
+	crdGVK := crdV1beta1GVR.GroupVersion().WithKind("CustomResourceDefinition")
+	require.Equal(t, []types.NamespacedName{namespacedName},
+		r.dependencies.dependentsOf(databaseGVK, reconcilerNs, backingServiceResourceRef))
+	require.Equal(t, []types.NamespacedName{namespacedName},
+		r.dependencies.dependentsOf(crdGVK, "", "databases."+mocks.CRDName))
+	require.Equal(t, []types.NamespacedName{namespacedName},
+		r.dependencies.dependentsOf(secretGVK, reconcilerNs, "db-credentials"))
+	require.True(t, watcher.record[crdGVK])
+	require.True(t, watcher.record[secretGVK])
+	deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	require.Contains(t, watcher.owned[namespacedName], deploymentGVK, "the application kind is watched")
+
+	// watches are released once the ServiceBinding is gone
+	r.dynClient = mocks.NewFake(t, reconcilerNs).FakeDynClient()
+	_, err = r.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.Empty(t, watcher.owned)
+}
+
+func TestReconcilerEvents(t *testing.T) {
+	backingServiceResourceRef := "test-events"
+	matchLabels := map[string]string{
+		"connects-to": "database",
+		"environment": "reconciler",
+	}
+
+	t.Run("successful binding", func(t *testing.T) {
+		f := mocks.NewFake(t, reconcilerNs)
+		f.AddMockedUnstructuredServiceBinding(reconcilerName, backingServiceResourceRef, reconcilerName, deploymentsGVR, matchLabels)
+		f.AddMockedUnstructuredCSV("cluster-service-version-list")
+		f.AddMockedUnstructuredDatabaseCRD()
+		f.AddMockedUnstructuredDatabaseCR(backingServiceResourceRef)
+		f.AddMockedUnstructuredDeployment(reconcilerName, matchLabels)
+		f.AddMockedUnstructuredSecret("db-credentials")
+
+		mapper := testutils.BuildTestRESTMapper()
+		recorder := record.NewFakeRecorder(10)
+		r := &reconciler{dynClient: f.FakeDynClient(), restMapper: mapper, scheme: f.S, recorder: recorder}
+		r.resourceWatcher = newFakeResourceWatcher(mapper)
+
+		_, err := r.Reconcile(reconcileRequest())
+		require.NoError(t, err)
+
+		require.Equal(t, []string{
+			`Normal SecretCreated Created secret "binding-request"`,
+			`Normal ApplicationBound Bound to ServiceBinding "testing/binding-request"`,
+			`Normal ApplicationBound Application Deployment "testing/binding-request" bound`,
+		}, drainEvents(recorder))
+	})
+
+	t.Run("service not found", func(t *testing.T) {
+		f := mocks.NewFake(t, reconcilerNs)
+		f.AddMockedUnstructuredServiceBinding(reconcilerName, backingServiceResourceRef, reconcilerName, deploymentsGVR, matchLabels)
+		f.AddMockedUnstructuredDatabaseCRD()
+		f.AddMockedUnstructuredDeployment(reconcilerName, matchLabels)
+
+		mapper := testutils.BuildTestRESTMapper()
+		recorder := record.NewFakeRecorder(10)
+		r := &reconciler{dynClient: f.FakeDynClient(), restMapper: mapper, scheme: f.S, recorder: recorder}
+		r.resourceWatcher = newFakeResourceWatcher(mapper)
+
+		res, _ := r.Reconcile(reconcileRequest())
+		require.True(t, res.Requeue)
+
+		events := drainEvents(recorder)
+		require.Len(t, events, 1)
+		require.Contains(t, events[0], "Warning ServiceNotFound")
+	})
+}
+
+// drainEvents returns all events recorded so far by the given recorder.
+func drainEvents(recorder *record.FakeRecorder) []string {
+	events := make([]string, 0)
+	for {
+		select {
+		case e := <-recorder.Events:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
 func TestReconcilerReconcileUsingVolumes(t *testing.T) {
 	t.Skip("there is not an equivalent yet for volume mounting")
 
@@ -314,6 +444,259 @@ func TestServiceNotFound(t *testing.T) {
 	require.Equal(t, 1, len(sbrOutput2.Status.Applications))
 }
 
+func TestServiceKindNotServed(t *testing.T) {
+	backingServiceResourceRef := "backingServiceRef"
+	applicationResourceRef := "applicationRef"
+	f := mocks.NewFake(t, reconcilerNs)
+	f.AddMockedUnstructuredServiceBinding(reconcilerName, backingServiceResourceRef, applicationResourceRef, deploymentsGVR, nil)
+	f.AddMockedUnstructuredDatabaseCR(backingServiceResourceRef)
+	f.AddMockedUnstructuredDeployment(applicationResourceRef, nil)
+
+	// the Database CRD isn't installed yet, so its kind is unknown to the RESTMapper
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(deploymentsGVR.GroupVersion().WithKind("Deployment"), meta.RESTScopeNamespace)
+	pending := newPendingKindTracker()
+	r := &reconciler{dynClient: f.FakeDynClient(), restMapper: mapper, scheme: f.S, pending: pending}
+	r.resourceWatcher = newFakeResourceWatcher(mapper)
+
+	// the Service Binding isn't requeued, but waits for the CRD to be installed
+	res, err := r.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.False(t, res.Requeue)
+
+	namespacedName := types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName}
+	sbrOutput, err := r.getServiceBinding(namespacedName)
+	require.NoError(t, err)
+	requireConditionPresentAndFalse(t, CollectionReady, sbrOutput.Status.Conditions)
+	collectionReady := conditionsv1.FindStatusCondition(sbrOutput.Status.Conditions, CollectionReady)
+	require.Equal(t, ServiceNotFoundReason, collectionReady.Reason)
+
+	require.Equal(t,
+		namespacedNameSet{namespacedName: true},
+		pending.installed(
+			schema.GroupKind{Group: "postgresql.baiju.dev", Kind: "Database"},
+			schema.GroupResource{Group: "postgresql.baiju.dev", Resource: "databases"},
+		),
+	)
+}
+
+func TestRequiredValueMissing(t *testing.T) {
+	backingServiceResourceRef := "backingServiceRef"
+	applicationResourceRef := "applicationRef"
+	f := mocks.NewFake(t, reconcilerNs)
+	f.AddMockedUnstructuredServiceBinding(reconcilerName, backingServiceResourceRef, applicationResourceRef, deploymentsGVR, nil)
+	f.AddMockedUnstructuredDatabaseCRD()
+	f.AddMockedUnstructuredDeployment(applicationResourceRef, nil)
+	require.NoError(t, pgapis.AddToScheme(f.S))
+	db, err := mocks.UnstructuredDatabaseCRMock(reconcilerNs, backingServiceResourceRef)
+	require.NoError(t, err)
+	db.SetAnnotations(map[string]string{
+		"service.binding/host":     "path={.status.dbHost},required=true",
+		"service.binding/port":     "path={.status.dbPort},optional=true",
+		"service.binding/database": "path={.spec.dbName}",
+	})
+	f.AddMockResource(db)
+
+	fakeDynClient := f.FakeDynClient()
+	mapper := testutils.BuildTestRESTMapper()
+	r := &reconciler{dynClient: fakeDynClient, restMapper: mapper, scheme: f.S}
+	r.resourceWatcher = newFakeResourceWatcher(mapper)
+
+	// the ServiceBinding isn't requeued, but reconciled again once the service changes
+	collections := stageSampleCount(t, serviceCollectionStage)
+	res, err := r.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.False(t, res.Requeue)
+	require.Equal(t, collections+1, stageSampleCount(t, serviceCollectionStage),
+		"the collection stage is observed when it fails")
+
+	namespacedName := types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName}
+	sbrOutput, err := r.getServiceBinding(namespacedName)
+	require.NoError(t, err)
+
+	requireConditionPresentAndFalse(t, CollectionReady, sbrOutput.Status.Conditions)
+	requireConditionPresentAndFalse(t, InjectionReady, sbrOutput.Status.Conditions)
+	requireConditionPresentAndFalse(t, BindingReady, sbrOutput.Status.Conditions)
+
+	collectionReady := conditionsv1.FindStatusCondition(sbrOutput.Status.Conditions, CollectionReady)
+	require.Equal(t, RequiredValueMissingReason, collectionReady.Reason)
+	require.Contains(t, collectionReady.Message, "service.binding/host")
+	require.Contains(t, collectionReady.Message, "{.status.dbHost}")
+
+	// the optional annotation is skipped quietly
+	keys := make([]string, 0)
+	for _, annErr := range sbrOutput.Status.AnnotationErrors {
+		keys = append(keys, annErr.Key)
+	}
+	require.Contains(t, keys, "service.binding/host")
+	require.NotContains(t, keys, "service.binding/port")
+}
+
+func TestServiceNotReady(t *testing.T) {
+	backingServiceResourceRef := "backingServiceRef"
+	applicationResourceRef := "applicationRef"
+	f := mocks.NewFake(t, reconcilerNs)
+	f.AddMockedUnstructuredServiceBinding(reconcilerName, backingServiceResourceRef, applicationResourceRef, deploymentsGVR, nil)
+	crd := f.AddMockedUnstructuredDatabaseCRD()
+	crd.SetAnnotations(map[string]string{readyConditionAnnotation: "Ready"})
+	f.AddMockedUnstructuredDatabaseCR(backingServiceResourceRef)
+	f.AddMockedUnstructuredDeployment(applicationResourceRef, nil)
+	f.AddMockedUnstructuredSecret("db-credentials")
+
+	fakeDynClient := f.FakeDynClient()
+	mapper := testutils.BuildTestRESTMapper()
+	r := &reconciler{dynClient: fakeDynClient, restMapper: mapper, scheme: f.S}
+	r.resourceWatcher = newFakeResourceWatcher(mapper)
+
+	// the ServiceBinding isn't requeued, but reconciled again once the service changes
+	res, err := r.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.False(t, res.Requeue)
+
+	namespacedName := types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName}
+	sbrOutput, err := r.getServiceBinding(namespacedName)
+	require.NoError(t, err)
+	requireConditionPresentAndFalse(t, CollectionReady, sbrOutput.Status.Conditions)
+	requireConditionPresentAndFalse(t, BindingReady, sbrOutput.Status.Conditions)
+	collectionReady := conditionsv1.FindStatusCondition(sbrOutput.Status.Conditions, CollectionReady)
+	require.Equal(t, ServiceNotReadyReason, collectionReady.Reason)
+	require.Contains(t, collectionReady.Message, "condition Ready is not reported")
+
+	// no binding secret is written for a service which isn't ready
+	_, err = fakeDynClient.Resource(secretsGVR).Namespace(reconcilerNs).Get(reconcilerName, metav1.GetOptions{})
+	require.True(t, errors.IsNotFound(err))
+
+	databasesGVR := schema.GroupVersionResource{Group: "postgresql.baiju.dev", Version: "v1alpha1", Resource: "databases"}
+	db, err := fakeDynClient.Resource(databasesGVR).Namespace(reconcilerNs).Get(backingServiceResourceRef, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NoError(t, unstructured.SetNestedSlice(db.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True"},
+	}, "status", "conditions"))
+	_, err = fakeDynClient.Resource(databasesGVR).Namespace(reconcilerNs).Update(db, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	_, err = r.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	sbrOutput, err = r.getServiceBinding(namespacedName)
+	require.NoError(t, err)
+	requireConditionPresentAndTrue(t, CollectionReady, sbrOutput.Status.Conditions)
+	require.Equal(t, reconcilerName, sbrOutput.Status.Secret)
+}
+
+func TestOptionalServiceMissing(t *testing.T) {
+	backingServiceResourceRef := "backingServiceRef"
+	applicationResourceRef := "applicationRef"
+	f := mocks.NewFake(t, reconcilerNs)
+	sbr := f.AddMockedUnstructuredServiceBinding(reconcilerName, backingServiceResourceRef, applicationResourceRef, deploymentsGVR, nil)
+	services, _, err := unstructured.NestedSlice(sbr.Object, "spec", "services")
+	require.NoError(t, err)
+	services = append(services, map[string]interface{}{
+		"version":  "v1",
+		"kind":     "ConfigMap",
+		"name":     "cache",
+		"optional": true,
+	})
+	require.NoError(t, unstructured.SetNestedSlice(sbr.Object, services, "spec", "services"))
+	f.AddMockedUnstructuredDatabaseCRD()
+	f.AddMockedUnstructuredDatabaseCR(backingServiceResourceRef)
+	f.AddMockedUnstructuredDeployment(applicationResourceRef, nil)
+	f.AddMockedUnstructuredSecret("db-credentials")
+
+	fakeDynClient := f.FakeDynClient()
+	mapper := testutils.BuildTestRESTMapper()
+	r := &reconciler{dynClient: fakeDynClient, restMapper: mapper, scheme: f.S, dependencies: newDependencyTracker()}
+	r.resourceWatcher = newFakeResourceWatcher(mapper)
+
+	// the binding proceeds without the optional service
+	res, err := r.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.False(t, res.Requeue)
+
+	namespacedName := types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName}
+	sbrOutput, err := r.getServiceBinding(namespacedName)
+	require.NoError(t, err)
+	requireConditionPresentAndTrue(t, BindingReady, sbrOutput.Status.Conditions)
+	requireConditionPresentAndTrue(t, Degraded, sbrOutput.Status.Conditions)
+	degraded := conditionsv1.FindStatusCondition(sbrOutput.Status.Conditions, Degraded)
+	require.Equal(t, OptionalServiceSkippedReason, degraded.Reason)
+	require.Contains(t, degraded.Message, `ConfigMap "cache"`)
+	require.Len(t, sbrOutput.Status.Services, 2)
+	require.Equal(t, v1alpha1.ServiceResolved, sbrOutput.Status.Services[0].State)
+	require.Equal(t, v1alpha1.ServiceMissing, sbrOutput.Status.Services[1].State)
+	require.True(t, sbrOutput.Status.Services[1].Optional)
+
+	// the missing service is tracked, so its creation triggers a new reconciliation
+	require.Equal(t, []types.NamespacedName{namespacedName}, r.dependencies.dependentsOf(
+		schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, reconcilerNs, "cache"))
+
+	cache, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: reconcilerNs, Name: "cache"},
+	})
+	require.NoError(t, err)
+	configMapsGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	_, err = fakeDynClient.Resource(configMapsGVR).Namespace(reconcilerNs).Create(
+		&unstructured.Unstructured{Object: cache}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, err = r.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	sbrOutput, err = r.getServiceBinding(namespacedName)
+	require.NoError(t, err)
+	requireConditionPresentAndTrue(t, BindingReady, sbrOutput.Status.Conditions)
+	requireConditionPresentAndFalse(t, Degraded, sbrOutput.Status.Conditions)
+	require.Equal(t, v1alpha1.ServiceResolved, sbrOutput.Status.Services[1].State)
+}
+
+func TestSecretAsService(t *testing.T) {
+	applicationResourceRef := "applicationRef"
+	f := mocks.NewFake(t, reconcilerNs)
+	sbr := f.AddMockedUnstructuredServiceBinding(reconcilerName, "", applicationResourceRef, deploymentsGVR, nil)
+	require.NoError(t, unstructured.SetNestedSlice(sbr.Object, []interface{}{
+		map[string]interface{}{
+			"version":      "v1",
+			"kind":         "Secret",
+			"name":         "db-credentials",
+			"envVarPrefix": "DB",
+			"id":           "db",
+		},
+	}, "spec", "services"))
+	require.NoError(t, unstructured.SetNestedSlice(sbr.Object, []interface{}{
+		map[string]interface{}{"name": "DB_URL", "value": "postgres://{{ .db.data.username }}@db.example.com"},
+	}, "spec", "customEnvVar"))
+	f.AddMockedUnstructuredDeployment(applicationResourceRef, nil)
+	f.AddMockedUnstructuredSecret("db-credentials")
+
+	fakeDynClient := f.FakeDynClient()
+	mapper := testutils.BuildTestRESTMapper()
+	r := &reconciler{dynClient: fakeDynClient, restMapper: mapper, scheme: f.S, dependencies: newDependencyTracker()}
+	r.resourceWatcher = newFakeResourceWatcher(mapper)
+
+	res, err := r.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.False(t, res.Requeue)
+
+	namespacedName := types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName}
+	sbrOutput, err := r.getServiceBinding(namespacedName)
+	require.NoError(t, err)
+	requireConditionPresentAndTrue(t, BindingReady, sbrOutput.Status.Conditions)
+
+	// the Secret entries are bound with the service prefix, and usable in templates
+	bindingSecret, err := fakeDynClient.Resource(secretsGVR).Namespace(reconcilerNs).Get(reconcilerName, metav1.GetOptions{})
+	require.NoError(t, err)
+	data, _, err := unstructured.NestedStringMap(bindingSecret.Object, "data")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"DB_USERNAME": base64.StdEncoding.EncodeToString([]byte("user")),
+		"DB_PASSWORD": base64.StdEncoding.EncodeToString([]byte("password")),
+		"DB_URL":      base64.StdEncoding.EncodeToString([]byte("postgres://user@db.example.com")),
+	}, data)
+
+	// changes on the Secret trigger a new reconciliation
+	require.Equal(t, []types.NamespacedName{namespacedName},
+		r.dependencies.dependentsOf(secretGVK, reconcilerNs, "db-credentials"))
+}
+
 func TestApplicationNotFound(t *testing.T) {
 	backingServiceResourceRef := "backingService1"
 	matchLabels := map[string]string{
@@ -511,6 +894,23 @@ func TestEmptyApplication(t *testing.T) {
 	requireConditionPresentAndTrue(t, BindingReady, sbrOutput.Status.Conditions)
 }
 
+func TestReconcilerSkipsOtherShards(t *testing.T) {
+	f := mocks.NewFake(t, reconcilerNs)
+	f.AddMockedUnstructuredServiceBindingWithoutApplication(reconcilerName, "backingService1")
+
+	mapper := testutils.BuildTestRESTMapper()
+	r := &reconciler{dynClient: f.FakeDynClient(), restMapper: mapper, scheme: f.S, shard: namespaceShard{}}
+	r.resourceWatcher = newFakeResourceWatcher(mapper)
+
+	res, err := r.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.Equal(t, reconcile.Result{}, res)
+
+	sbrOutput, err := r.getServiceBinding(types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName})
+	require.NoError(t, err)
+	require.Empty(t, sbrOutput.Status.Conditions, "ServiceBindings owned by other replicas are left untouched")
+}
+
 // TestEmptyServiceSelector tests that CollectionReady,InjectionReady and BindingReady are all successfully updated to True when ServiceSelector is empty
 func TestEmptyServiceSelectorAndAllConditionAreSetToFalse(t *testing.T) {
 	applicationResourceRef := "applicationRef"
@@ -648,3 +1048,62 @@ func TestBindTwoSbrsWithSingleApplication(t *testing.T) {
 		require.Equal(t, sbrName2, dep.Spec.Template.Spec.Containers[0].EnvFrom[1].SecretRef.LocalObjectReference.Name)
 	})
 }
+
+// TestReconcilerAppliesNamespaceDefaults checks the namespace defaults are used for the settings
+// the ServiceBinding leaves unset.
+func TestReconcilerAppliesNamespaceDefaults(t *testing.T) {
+	backingServiceResourceRef := "test-namespace-defaults"
+	matchLabels := map[string]string{
+		"connects-to": "database",
+		"environment": "defaults",
+	}
+	f := mocks.NewFake(t, reconcilerNs)
+	f.AddMockedUnstructuredServiceBinding(reconcilerName, backingServiceResourceRef, reconcilerName, deploymentsGVR, matchLabels)
+	f.AddMockedUnstructuredCSV("cluster-service-version-list")
+	f.AddMockedUnstructuredDatabaseCRD()
+	f.AddMockedUnstructuredDatabaseCR(backingServiceResourceRef)
+	f.AddMockedUnstructuredDeployment(reconcilerName, matchLabels)
+	f.AddMockedUnstructuredSecret("db-credentials")
+
+	fakeDynClient := f.FakeDynClient()
+	mapper := testutils.BuildTestRESTMapper()
+	r := &reconciler{dynClient: fakeDynClient, restMapper: mapper, scheme: f.S}
+	r.resourceWatcher = newFakeResourceWatcher(mapper)
+
+	defaults, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&v1alpha1.ServiceBindingDefaults{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "ServiceBindingDefaults"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: reconcilerNs, Name: serviceBindingDefaultsName},
+		Spec: v1alpha1.ServiceBindingDefaultsSpec{
+			MountPathPrefix: "/team",
+			EnvVarPrefix:    "TEAM",
+		},
+	})
+	require.NoError(t, err)
+	_, err = fakeDynClient.Resource(serviceBindingDefaultsResource).Namespace(reconcilerNs).
+		Create(&unstructured.Unstructured{Object: defaults}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	res, err := r.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.False(t, res.Requeue)
+
+	sbrOutput, err := r.getServiceBinding(types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName})
+	require.NoError(t, err)
+	requireConditionPresentAndTrue(t, BindingReady, sbrOutput.Status.Conditions)
+
+	effective := sbrOutput.Status.Effective
+	require.NotNil(t, effective)
+	require.Equal(t, "/var/redhat", effective.MountPathPrefix, "the declared mount path prefix wins")
+	require.Equal(t, "TEAM", effective.EnvVarPrefix)
+	require.Equal(t, serviceBindingDefaultsName, effective.Defaults)
+
+	require.Contains(t, sbrOutput.GetFinalizers(), finalizer)
+
+	secret, err := fakeDynClient.Resource(secretsGVR).Namespace(reconcilerNs).Get(reconcilerName, metav1.GetOptions{})
+	require.NoError(t, err)
+	data, _, err := unstructured.NestedMap(secret.Object, "data")
+	require.NoError(t, err)
+	for key := range data {
+		require.Regexp(t, "^TEAM_", key)
+	}
+}
@@ -1,9 +1,9 @@
 package servicebinding
 
 import (
-	"os"
 	"reflect"
 	"strings"
+	"sync"
 
 	olmv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -12,7 +12,12 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -29,6 +34,10 @@ import (
 type ResourceWatcher interface {
 	AddWatchForGVR(schema.GroupVersionResource) error
 	AddWatchForGVK(schema.GroupVersionKind) error
+	// SetWatchesFor replaces the GVKs watched on behalf of the given ServiceBinding.
+	SetWatchesFor(types.NamespacedName, []schema.GroupVersionKind) error
+	// ReleaseWatchesFor stops watching GVKs on behalf of the given ServiceBinding.
+	ReleaseWatchesFor(types.NamespacedName)
 }
 
 // sbrController hold the controller instance and methods for a ServiceBinding.
@@ -37,6 +46,15 @@ type sbrController struct {
 	Client       dynamic.Interface                // kubernetes dynamic api client
 	RestMapper   meta.RESTMapper                  // restMapper to convert GVK and GVR
 	watchingGVKs map[schema.GroupVersionKind]bool // cache to identify GVKs on watch
+	watchingMu   sync.Mutex                       // guards watchingGVKs
+	dynamic      *dynamicWatches                  // watches stopped once no owner needs them
+	dependencies *dependencyTracker               // objects referenced by each ServiceBinding
+	pending      *pendingKindTracker              // ServiceBindings waiting for kinds to be served
+	index        *serviceBindingIndex             // ServiceBindings indexed by related objects
+	namespaces   watchNamespaces                  // namespaces the operator is restricted to
+	shard        shard                            // namespaces reconciled, all of them when nil
+	resync       chan event.GenericEvent          // ServiceBindings to reconcile again
+	watches      *watchStatus                     // watches set up again until they succeed
 	logger       *log.Log                         // logger instance
 }
 
@@ -67,8 +85,11 @@ func compareObjectFields(objOld, objNew runtime.Object, fields ...string) (*comp
 // ServiceBinding if it contains the required configuration.
 func (s *sbrController) newEnqueueRequestsForSBR() handler.EventHandler {
 	return &handler.EnqueueRequestsFromMapFunc{ToRequests: &sbrRequestMapper{
-		client:     s.Client,
-		restMapper: s.RestMapper,
+		index:        s.index,
+		restMapper:   s.RestMapper,
+		dependencies: s.dependencies,
+		namespaces:   s.namespaces,
+		shard:        s.shard,
 	}}
 }
 
@@ -84,23 +105,15 @@ func (s *sbrController) createUnstructuredWithGVK(gvk schema.GroupVersionKind) *
 	return u
 }
 
-// getWatchingGVKs return a list of GVKs that this controller is interested in watching.
+// getWatchingGVKs return a list of GVKs that this controller is interested in watching from
+// startup. GVKs owned by CSVs are watched as the CSVs are observed, see csvToWatcherMapper.
 func (s *sbrController) getWatchingGVKs() ([]schema.GroupVersionKind, error) {
-	log := s.logger
 	// standard resources types
 	gvks := []schema.GroupVersionKind{
 		{Group: "", Version: "v1", Kind: "Secret"},
 		{Group: "", Version: "v1", Kind: "ConfigMap"},
 	}
-
-	olm := newOLM(s.Client, os.Getenv("WATCH_NAMESPACE"))
-	olmGVKs, err := olm.listCSVOwnedCRDsAsGVKs()
-	if err != nil {
-		log.Error(err, "On listing owned CSV as GVKs")
-		return nil, err
-	}
-	log.Debug("Amount of GVK founds in CSV objects.", "CSVOwnedGVK.Amount", len(olmGVKs))
-	return append(gvks, olmGVKs...), nil
+	return gvks, nil
 }
 
 // isOfKind evaluates whether the given object has a specific kind.
@@ -188,6 +201,8 @@ func buildGVKPredicate(logger *log.Log) predicate.Funcs {
 func (s *sbrController) AddWatchForGVK(gvk schema.GroupVersionKind) error {
 	logger := s.logger.WithValues("GVK", gvk)
 	logger.Trace("Adding watch for GVK...")
+	s.watchingMu.Lock()
+	defer s.watchingMu.Unlock()
 	if _, exists := s.watchingGVKs[gvk]; exists {
 		logger.Trace("Skipping watch on GVK twice, it's already under watch!")
 		return nil
@@ -195,10 +210,97 @@ func (s *sbrController) AddWatchForGVK(gvk schema.GroupVersionKind) error {
 
 	// saving GVK in cache
 	s.watchingGVKs[gvk] = true
+	activeWatchesGauge.WithLabelValues(permanentWatchType).Inc()
 
 	logger.Debug("Creating watch on GVK")
 	src := s.createSourceForGVK(gvk)
-	return s.Controller.Watch(src, s.newEnqueueRequestsForSBR(), buildGVKPredicate(logger))
+	if err := s.Controller.Watch(src, s.newEnqueueRequestsForSBR(), buildGVKPredicate(logger)); err != nil {
+		// the watch is created again on the next attempt
+		delete(s.watchingGVKs, gvk)
+		activeWatchesGauge.WithLabelValues(permanentWatchType).Dec()
+		return err
+	}
+	return nil
+}
+
+// isPermanentWatch returns whether the given GVK is watched for the whole operator lifetime: kinds
+// read from the informer cache, whose informers can't be stopped, and bindable resources.
+func (s *sbrController) isPermanentWatch(gvk schema.GroupVersionKind) bool {
+	for _, bindable := range bindableResources.kinds() {
+		if bindable == gvk {
+			return true
+		}
+	}
+	mapping, err := s.RestMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return false
+	}
+	for _, gvr := range cachedResources {
+		if gvr == mapping.Resource {
+			return true
+		}
+	}
+	return false
+}
+
+// setWatches replaces the GVKs watched on behalf of the given owner; permanent GVKs are watched
+// through AddWatchForGVK, all others are reference-counted.
+func (s *sbrController) setWatches(owner watchOwner, gvks []schema.GroupVersionKind) error {
+	var errs []error
+	dynamic := make([]schema.GroupVersionKind, 0, len(gvks))
+	for _, gvk := range gvks {
+		if !s.isPermanentWatch(gvk) {
+			dynamic = append(dynamic, gvk)
+		} else if err := s.AddWatchForGVK(gvk); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := s.dynamic.set(owner, dynamic); err != nil {
+		errs = append(errs, err)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// SetWatchesFor replaces the GVKs watched on behalf of the given ServiceBinding.
+func (s *sbrController) SetWatchesFor(sbr types.NamespacedName, gvks []schema.GroupVersionKind) error {
+	return s.setWatches(watchOwner{kind: serviceBindingOwner, NamespacedName: sbr}, gvks)
+}
+
+// ReleaseWatchesFor stops watching GVKs on behalf of the given ServiceBinding; watches no other
+// owner needs are stopped.
+func (s *sbrController) ReleaseWatchesFor(sbr types.NamespacedName) {
+	s.dynamic.release(watchOwner{kind: serviceBindingOwner, NamespacedName: sbr})
+}
+
+// startDynamicWatch starts dedicated informers for the given GVK, one per watched namespace for
+// namespaced kinds, feeding the controller until the returned channel is closed.
+func (s *sbrController) startDynamicWatch(gvk schema.GroupVersionKind) (chan struct{}, error) {
+	logger := s.logger.WithValues("GVK", gvk)
+	mapping, err := s.RestMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := []string{""}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespaces = s.namespaces.listed()
+	}
+
+	informers := make([]toolscache.SharedIndexInformer, 0, len(namespaces))
+	for _, ns := range namespaces {
+		informer := dynamicinformer.NewFilteredDynamicInformer(
+			s.Client, mapping.Resource, ns, 0, toolscache.Indexers{}, nil).Informer()
+		logger.Debug("Creating dynamic watch on GVK", "Namespace", ns)
+		src := &source.Informer{Informer: informer}
+		if err := s.Controller.Watch(src, s.newEnqueueRequestsForSBR(), buildGVKPredicate(logger)); err != nil {
+			return nil, err
+		}
+		informers = append(informers, informer)
+	}
+	stop := make(chan struct{})
+	for _, informer := range informers {
+		go informer.Run(stop)
+	}
+	return stop, nil
 }
 
 // AddWatchForGVR creates a watch on a given GVR
@@ -214,7 +316,7 @@ func (s *sbrController) AddWatchForGVR(gvr schema.GroupVersionResource) error {
 func (s *sbrController) addCSVWatch() error {
 	log := s.logger
 	gvr := olmv1alpha1.SchemeGroupVersion.WithResource(csvResource)
-	resourceClient := s.Client.Resource(gvr).Namespace(os.Getenv("WATCH_NAMESPACE"))
+	resourceClient := s.Client.Resource(gvr).Namespace(s.namespaces.listed()[0])
 	_, err := resourceClient.List(metav1.ListOptions{})
 	if err != nil && errors.IsNotFound(err) {
 		log.Warning("ClusterServiceVersions CRD is not installed, skip watching")
@@ -233,6 +335,88 @@ func (s *sbrController) addCSVWatch() error {
 	return nil
 }
 
+// addCRDWatch creates a watch on CRDs, refreshing the RESTMapper when they change and reconciling the
+// ServiceBindings waiting for the kinds they declare.
+func (s *sbrController) addCRDWatch() error {
+	log := s.logger
+	for _, gvr := range []schema.GroupVersionResource{crdGVR, crdV1beta1GVR} {
+		gvk, err := s.RestMapper.KindFor(gvr)
+		if err != nil {
+			continue
+		}
+		eventHandler := &crdEventHandler{
+			resetter: newRESTMapperResetter(s.RestMapper, restMapperResetInterval),
+			pending:  s.pending,
+		}
+		if err := s.Controller.Watch(s.createSourceForGVK(gvk), eventHandler); err != nil {
+			return err
+		}
+		log.Debug("Watch added for CustomResourceDefinition", "GVK", gvk)
+		return nil
+	}
+	log.Warning("CustomResourceDefinitions are not served, skip watching")
+	return nil
+}
+
+// addBindableResourceWatches creates watches on the bindable resources known to the cluster.
+func (s *sbrController) addBindableResourceWatches() {
+	for _, gvk := range bindableResources.kinds() {
+		logger := s.logger.WithValues("GVK", gvk)
+		if _, err := s.RestMapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			logger.Debug("Bindable resource is not available in the cluster, skip watching")
+			continue
+		}
+		gvk := gvk
+		err := s.watches.setUp(gvk.String(), func() error {
+			return s.AddWatchForGVK(gvk)
+		})
+		if err != nil {
+			logger.Error(err, "on creating watch for bindable resource")
+		}
+	}
+}
+
+// addResyncWatch creates a watch on the resync channel, so ServiceBindings sent on it are
+// reconciled, when owned by the operator replica.
+func (s *sbrController) addResyncWatch() error {
+	owned := predicate.Funcs{
+		GenericFunc: func(e event.GenericEvent) bool {
+			return ownsNamespace(s.shard, e.Meta.GetNamespace())
+		},
+	}
+	return s.Controller.Watch(&source.Channel{Source: s.resync}, &handler.EnqueueRequestForObject{}, owned)
+}
+
+// resyncAll reconciles again all ServiceBindings found in the informer cache.
+func (s *sbrController) resyncAll() {
+	if s.index == nil {
+		return
+	}
+	objs := s.index.list()
+	events := make([]event.GenericEvent, 0, len(objs))
+	for _, obj := range objs {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			s.logger.Error(err, "on accessing ServiceBinding metadata")
+			continue
+		}
+		events = append(events, event.GenericEvent{Meta: accessor, Object: obj.(runtime.Object)})
+	}
+	// events are only consumed once the controller is started
+	go func() {
+		for _, e := range events {
+			s.resync <- e
+		}
+	}()
+}
+
+// onBindableResourcesChange watches the bindable resources declared in the configuration and
+// reconciles all ServiceBindings again, so their owned resources are collected once more.
+func (s *sbrController) onBindableResourcesChange() {
+	s.addBindableResourceWatches()
+	s.resyncAll()
+}
+
 // buildSBRPredicate construct the predicates for service-bindings.
 func buildSBRPredicate(logger *log.Log) predicate.Funcs {
 	logger = logger.WithName("buildSBRPredicate")
@@ -321,32 +505,62 @@ func (s *sbrController) Watch() error {
 		return err
 	}
 
-	err = s.addCSVWatch()
-	if err != nil {
+	// the operator runs without the OLM and CRD watches until they're set up, reporting itself as
+	// not ready meanwhile
+	if err := s.watches.setUp("ClusterServiceVersion", s.addCSVWatch); err != nil {
 		log.Error(err, "on adding watch for ClusterServiceVersion")
+	}
+
+	if err := s.watches.setUp("CustomResourceDefinition", s.addCRDWatch); err != nil {
+		log.Error(err, "on adding watch for CustomResourceDefinition")
+	}
+
+	err = s.addResyncWatch()
+	if err != nil {
+		log.Error(err, "on adding watch for ServiceBindings resync")
 		return err
 	}
 
+	s.addBindableResourceWatches()
+
 	return nil
 }
 
 // NewSBRController creates a new SBRController instance. It can return error on bootstrapping a new
-// dynamic client.
+// dynamic client. ServiceBindings are handed out to workers round-robin across namespaces, and
+// failed reconciliations are retried following the operator configuration backoff.
 func NewSBRController(
 	mgr manager.Manager,
 	options controller.Options,
 	client dynamic.Interface,
 ) (*sbrController, error) {
-	c, err := controller.New(controllerName, mgr, options)
+	c, err := newQueueController(controllerName, mgr, options, func() workqueue.RateLimitingInterface {
+		return newFairQueue(controllerName, newBackoffRateLimiter())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the ServiceBinding informer is shared with the ServiceBinding watch, which is also unstructured
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(serviceBindingRequestKind))
+	informer, err := mgr.GetCache().GetInformer(u)
 	if err != nil {
 		return nil, err
 	}
+	index := newServiceBindingIndex(informer)
 
-	return &sbrController{
+	s := &sbrController{
 		Controller:   c,
 		Client:       client,
 		RestMapper:   mgr.GetRESTMapper(),
 		watchingGVKs: make(map[schema.GroupVersionKind]bool),
+		index:        index,
+		namespaces:   getWatchNamespaces(),
+		resync:       make(chan event.GenericEvent),
+		watches:      newWatchStatus(),
 		logger:       log.NewLog("sbrcontroller"),
-	}, nil
+	}
+	s.dynamic = newDynamicWatches(s.startDynamicWatch)
+	return s, nil
 }
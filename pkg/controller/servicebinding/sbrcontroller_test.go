@@ -6,12 +6,15 @@ import (
 	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
 	"github.com/redhat-developer/service-binding-operator/pkg/log"
 	"github.com/redhat-developer/service-binding-operator/pkg/testutils"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -417,3 +420,107 @@ func TestSBRController_ResourceWatcher(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestSBRController_OnBindableResourcesChange(t *testing.T) {
+	sbrs := []*v1alpha1.ServiceBinding{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "sbr-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "sbr-2"}},
+	}
+	watched := make(map[schema.GroupVersionKind]bool)
+	controller := &sbrController{
+		Controller: &fakeController{
+			watchCallback: func(src source.Source, _ handler.EventHandler, _ ...predicate.Predicate) error {
+				kind, ok := src.(*source.Kind)
+				require.True(t, ok)
+				watched[kind.Type.GetObjectKind().GroupVersionKind()] = true
+				return nil
+			},
+		},
+		RestMapper:   testutils.BuildTestRESTMapper(),
+		watchingGVKs: make(map[schema.GroupVersionKind]bool),
+		index:        newTestServiceBindingIndex(t, sbrs...),
+		resync:       make(chan event.GenericEvent),
+		logger:       log.NewLog("testSBRController"),
+	}
+
+	controller.onBindableResourcesChange()
+
+	resynced := make(map[string]bool)
+	for range sbrs {
+		e := <-controller.resync
+		resynced[e.Meta.GetName()] = true
+	}
+	require.Equal(t, map[string]bool{"sbr-1": true, "sbr-2": true}, resynced)
+
+	// routes are unknown to the RESTMapper, and therefore aren't watched
+	require.Equal(t, map[schema.GroupVersionKind]bool{
+		{Version: "v1", Kind: "ConfigMap"}: true,
+		{Version: "v1", Kind: "Secret"}:    true,
+		{Version: "v1", Kind: "Service"}:   true,
+	}, watched)
+}
+
+func TestSBRController_AddCRDWatch(t *testing.T) {
+	var watched []schema.GroupVersionKind
+	controller := &sbrController{
+		Controller: &fakeController{
+			watchCallback: func(src source.Source, _ handler.EventHandler, _ ...predicate.Predicate) error {
+				kind, ok := src.(*source.Kind)
+				require.True(t, ok)
+				watched = append(watched, kind.Type.GetObjectKind().GroupVersionKind())
+				return nil
+			},
+		},
+		logger: log.NewLog("testSBRController"),
+	}
+
+	// CRDs aren't known to the RESTMapper
+	controller.RestMapper = testutils.BuildTestRESTMapper()
+	require.NoError(t, controller.addCRDWatch())
+	require.Empty(t, watched)
+
+	// v1beta1 CRDs are watched when v1 isn't served
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(crdV1beta1GVR.GroupVersion().WithKind("CustomResourceDefinition"), meta.RESTScopeRoot)
+	controller.RestMapper = restMapper
+	require.NoError(t, controller.addCRDWatch())
+	require.Equal(t, []schema.GroupVersionKind{crdV1beta1GVR.GroupVersion().WithKind("CustomResourceDefinition")}, watched)
+}
+
+func TestSBRController_SetWatchesFor(t *testing.T) {
+	f := mocks.NewFake(t, "watches")
+	var sources []source.Source
+	controller := &sbrController{
+		Controller: &fakeController{
+			watchCallback: func(src source.Source, _ handler.EventHandler, _ ...predicate.Predicate) error {
+				sources = append(sources, src)
+				return nil
+			},
+		},
+		Client:       f.FakeDynClient(),
+		RestMapper:   buildCachedClientTestRESTMapper(),
+		watchingGVKs: make(map[schema.GroupVersionKind]bool),
+		logger:       log.NewLog("testSBRController"),
+	}
+	controller.dynamic = newDynamicWatches(controller.startDynamicWatch)
+	sbr := types.NamespacedName{Namespace: "watches", Name: "binding"}
+	secretGVK := schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+	databaseGVK := schema.GroupVersionKind{Group: mocks.CRDName, Version: mocks.CRDVersion, Kind: mocks.CRDKind}
+
+	// kinds read from the informer cache are watched permanently, others through a dedicated informer
+	require.NoError(t, controller.SetWatchesFor(sbr, []schema.GroupVersionKind{secretGVK, databaseGVK}))
+	require.True(t, controller.watchingGVKs[secretGVK])
+	require.False(t, controller.watchingGVKs[databaseGVK])
+	require.Equal(t, 1, controller.dynamic.owners(databaseGVK))
+	require.Len(t, sources, 2)
+	_, ok := sources[1].(*source.Informer)
+	require.True(t, ok)
+
+	controller.ReleaseWatchesFor(sbr)
+	require.Equal(t, 0, controller.dynamic.owners(databaseGVK))
+	require.True(t, controller.watchingGVKs[secretGVK])
+
+	// unknown kinds can't be watched
+	unknownGVK := schema.GroupVersionKind{Group: "unknown", Version: "v1", Kind: "Unknown"}
+	require.Error(t, controller.SetWatchesFor(sbr, []schema.GroupVersionKind{unknownGVK}))
+}
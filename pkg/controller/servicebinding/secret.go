@@ -1,6 +1,7 @@
 package servicebinding
 
 import (
+	"context"
 	"encoding/base64"
 
 	corev1 "k8s.io/api/core/v1"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/redhat-developer/service-binding-operator/pkg/converter"
 	"github.com/redhat-developer/service-binding-operator/pkg/log"
+	"github.com/redhat-developer/service-binding-operator/pkg/tracing"
 )
 
 // secret represents the data collected by this operator, and later handled as a secret.
@@ -27,9 +29,29 @@ func (s *secret) buildResourceClient() dynamic.ResourceInterface {
 	return s.client.Resource(gvr).Namespace(s.ns)
 }
 
+// withContext returns a copy of the secret which API calls are traced as children of ctx.
+func (s *secret) withContext(ctx context.Context) *secret {
+	c := *s
+	c.client = tracing.NewDynamicClient(ctx, s.client)
+	return &c
+}
+
+// secretOperation describes the change createOrUpdate applied to the secret.
+type secretOperation string
+
+const (
+	secretCreated   secretOperation = "created"
+	secretUpdated   secretOperation = "updated"
+	secretUnchanged secretOperation = "unchanged"
+)
+
 // createOrUpdate will take informed payload and either create a new secret or update an existing
-// one. It can return error when Kubernetes client does.
-func (s *secret) createOrUpdate(payload map[string][]byte, ownerReference metav1.OwnerReference) (*unstructured.Unstructured, error) {
+// one, returning the secret as stored in the cluster and which operation has been performed. It can
+// return error when Kubernetes client does.
+func (s *secret) createOrUpdate(
+	payload map[string][]byte,
+	ownerReference metav1.OwnerReference,
+) (*unstructured.Unstructured, secretOperation, error) {
 	logger := s.logger.WithValues("Namespace", s.ns, "Name", s.name)
 	secretObj := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -43,7 +65,7 @@ func (s *secret) createOrUpdate(payload map[string][]byte, ownerReference metav1
 	gvk := corev1.SchemeGroupVersion.WithKind(secretKind)
 	u, err := converter.ToUnstructuredAsGVK(secretObj, gvk)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	resourceClient := s.buildResourceClient()
@@ -52,15 +74,15 @@ func (s *secret) createOrUpdate(payload map[string][]byte, ownerReference metav1
 	existingSecret, err := s.get()
 	if err != nil {
 		if errors.IsNotFound(err) {
-			_, err := resourceClient.Create(u, metav1.CreateOptions{})
+			created, err := resourceClient.Create(u, metav1.CreateOptions{})
 			if err != nil {
 				logger.Error(err, "Error creating secret")
-				return nil, err
+				return nil, "", err
 			}
 			logger.Info("Secret created")
-			return u, nil
+			return created, secretCreated, nil
 		}
-		return nil, err
+		return nil, "", err
 	}
 	existingSecretData, _, _ := unstructured.NestedMap(existingSecret.Object, "data")
 	payloadInterim := make(map[string]interface{})
@@ -70,14 +92,14 @@ func (s *secret) createOrUpdate(payload map[string][]byte, ownerReference metav1
 	comparisonResult := nestedMapComparison(existingSecretData, payloadInterim)
 	if comparisonResult.Success {
 		logger.Debug("Secret data is same. Skip Update")
-	} else {
-		logger.Info("Secret data is different; update secret", "Diff", comparisonResult.Diff)
-		_, err = resourceClient.Update(u, metav1.UpdateOptions{})
-		if err != nil {
-			return nil, err
-		}
+		return existingSecret, secretUnchanged, nil
 	}
-	return u, nil
+	logger.Info("Secret data is different; update secret", "Diff", comparisonResult.Diff)
+	updated, err := resourceClient.Update(u, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, "", err
+	}
+	return updated, secretUpdated, nil
 }
 
 // get an unstructured object from the secret handled by this component. It can return errors in case
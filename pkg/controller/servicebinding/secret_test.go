@@ -41,9 +41,18 @@ func TestSecretNew(t *testing.T) {
 	)
 
 	t.Run("createOrUpdate", func(t *testing.T) {
-		u, err := s.createOrUpdate(data, secretOwnerReference)
+		u, op, err := s.createOrUpdate(data, secretOwnerReference)
 		assert.NoError(t, err)
+		assert.Equal(t, secretCreated, op)
 		assertSecretNamespacedName(t, u, ns, name)
+
+		_, op, err = s.createOrUpdate(data, secretOwnerReference)
+		assert.NoError(t, err)
+		assert.Equal(t, secretUnchanged, op)
+
+		_, op, err = s.createOrUpdate(map[string][]byte{"key": []byte("other")}, secretOwnerReference)
+		assert.NoError(t, err)
+		assert.Equal(t, secretUpdated, op)
 	})
 
 	t.Run("Get", func(t *testing.T) {
@@ -1,12 +1,16 @@
 package servicebinding
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -15,6 +19,7 @@ import (
 	"k8s.io/client-go/dynamic"
 
 	olmv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
 	"github.com/redhat-developer/service-binding-operator/pkg/controller/servicebinding/nested"
 	"github.com/redhat-developer/service-binding-operator/pkg/controller/servicebinding/binding"
 	"github.com/redhat-developer/service-binding-operator/pkg/log"
@@ -39,37 +44,112 @@ func findService(
 	ns string,
 	gvk schema.GroupVersionKind,
 	name string,
+	restMapper meta.RESTMapper,
 ) (
 	*unstructured.Unstructured,
 	error,
 ) {
-	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
-
 	if len(ns) == 0 {
 		return nil, errUnspecifiedBackingServiceNamespace
 	}
 
+	mapping, err := restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+
 	// delegate the search selector's namespaced resource client
 	return client.
-		Resource(gvr).
+		Resource(mapping.Resource).
 		Namespace(ns).
 		Get(name, metav1.GetOptions{})
 }
 
+// configMapGVK is the GVK of core ConfigMaps.
+var configMapGVK = corev1.SchemeGroupVersion.WithKind("ConfigMap")
+
+// directServiceData returns the entries of the given service when it's a core Secret or ConfigMap,
+// which are bound as is when the service declares no binding annotation; Secret values are
+// decoded.
+func directServiceData(obj *unstructured.Unstructured) (map[string]interface{}, bool, error) {
+	gvk := obj.GroupVersionKind()
+	if gvk != secretGVK && gvk != configMapGVK {
+		return nil, false, nil
+	}
+	data, _, err := unstructured.NestedStringMap(obj.Object, "data")
+	if err != nil {
+		return nil, false, err
+	}
+	values := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		if gvk == secretGVK {
+			b, err := base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				return nil, false, fmt.Errorf("decoding %q of Secret %q: %s", k, obj.GetName(), err)
+			}
+			v = string(b)
+		}
+		values[k] = v
+	}
+	return values, true, nil
+}
+
 // crdGVR is the plural GVR for Kubernetes CRDs.
 var crdGVR = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1",
+	Resource: "customresourcedefinitions",
+}
+
+// crdV1beta1GVR is the plural GVR for Kubernetes CRDs, on clusters not serving crdGVR yet.
+var crdV1beta1GVR = schema.GroupVersionResource{
 	Group:    "apiextensions.k8s.io",
 	Version:  "v1beta1",
 	Resource: "customresourcedefinitions",
 }
 
-func findServiceCRD(client dynamic.Interface, gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
-	// gvr is the plural guessed resource for the given GVK
-	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+func findServiceCRD(
+	client dynamic.Interface,
+	gvk schema.GroupVersionKind,
+	restMapper meta.RESTMapper,
+) (*unstructured.Unstructured, error) {
+	mapping, err := restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
 	// crdName is the string'fied GroupResource, e.g. "deployments.apps"
-	crdName := gvr.GroupResource().String()
-	// delegate the search to the CustomResourceDefinition resource client
-	return client.Resource(crdGVR).Get(crdName, metav1.GetOptions{})
+	crdName := mapping.Resource.GroupResource().String()
+	// delegate the search to the CustomResourceDefinition resource client, falling back to v1beta1
+	// when v1 CRDs aren't served
+	crd, err := client.Resource(crdGVR).Get(crdName, metav1.GetOptions{})
+	if err != nil && (k8serrors.IsNotFound(err) || meta.IsNoMatchError(err)) {
+		return client.Resource(crdV1beta1GVR).Get(crdName, metav1.GetOptions{})
+	}
+	return crd, err
+}
+
+// crdVersionPrefix matches the version qualifying a CRD annotation, as in
+// "v1alpha1.service.binding/username".
+var crdVersionPrefix = regexp.MustCompile(`^(v[0-9]+(?:(?:alpha|beta)[0-9]+)?)\.`)
+
+// crdAnnotations returns the CRD annotations applying to the given version: annotations qualified
+// with the version override unqualified ones, and annotations qualified with other versions are
+// left out.
+func crdAnnotations(crd *unstructured.Unstructured, version string) map[string]string {
+	anns := make(map[string]string)
+	versioned := make(map[string]string)
+	for k, v := range crd.GetAnnotations() {
+		m := crdVersionPrefix.FindStringSubmatch(k)
+		if m == nil {
+			anns[k] = v
+		} else if m[1] == version {
+			versioned[strings.TrimPrefix(k, m[0])] = v
+		}
+	}
+	for k, v := range versioned {
+		anns[k] = v
+	}
+	return anns
 }
 
 func loadDescriptor(anns map[string]string, path string, descriptor string, root string, objectType string) {
@@ -138,6 +218,8 @@ func findCRDDescription(
 	return newOLM(client, ns).selectCRDByGVK(bssGVK, crd)
 }
 
+// bindableResource is a resource type, owned by services, whose value in inputPath is collected
+// under outputPath.
 type bindableResource struct {
 	gvk        schema.GroupVersionKind
 	gvr        schema.GroupVersionResource
@@ -145,7 +227,12 @@ type bindableResource struct {
 	outputPath string
 }
 
-var bindableResources = []bindableResource{
+// bindableResources holds the bindable resources in use, kept in sync with the configuration
+// ConfigMap by the controller.
+var bindableResources = newBindableResourceRegistry(defaultBindableResources...)
+
+// defaultBindableResources are the built-in bindable resources.
+var defaultBindableResources = []bindableResource{
 	{
 		gvk:        schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"},
 		gvr:        schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"},
@@ -176,37 +263,63 @@ var bindableResources = []bindableResource{
 	},
 }
 
+// detectedResource is a bindable resource related to a service.
+type detectedResource struct {
+	obj *unstructured.Unstructured
+	// association is how the resource is related to the service.
+	association string
+}
+
+// getOwnedResources returns the bindable resources related to the given service: the ones owned by
+// the service within the configured ownership depth, and the ones having the configured association
+// labels. Each resource is returned once, owned resources first.
 func getOwnedResources(
 	logger *log.Log,
 	client dynamic.Interface,
 	ns string,
-	gvk schema.GroupVersionKind,
 	name string,
 	uid types.UID,
+	detection *v1alpha1.BindingResourcesDetection,
+	restMapper meta.RESTMapper,
 ) (
-	[]*unstructured.Unstructured,
+	[]detectedResource,
 	error,
 ) {
-	var resources []*unstructured.Unstructured
-	for _, br := range bindableResources {
-		lst, err := client.Resource(br.gvr).Namespace(ns).List(metav1.ListOptions{})
+	depth := defaultOwnershipDepth
+	var selector labels.Selector
+	if detection != nil {
+		if detection.OwnershipDepth != nil {
+			depth = int(*detection.OwnershipDepth)
+		}
+		selector = labelAssociationsSelector(detection.LabelAssociations, name)
+	}
+	owners := newOwnershipResolver(client, restMapper, ns)
+
+	// each resource type is listed once, and each resource is associated once
+	var owned, labeled []detectedResource
+	for _, gvr := range bindableResources.resources() {
+		lst, err := client.Resource(gvr).Namespace(ns).List(metav1.ListOptions{})
 		if err != nil {
 			if k8serrors.IsNotFound(err) {
 				logger.Debug("Resource not found in Bindable Resources", "Error", err)
 				continue
 			}
-			return resources, err
+			return nil, err
 		}
-		for idx, item := range lst.Items {
-			owners := item.GetOwnerReferences()
-			for _, owner := range owners {
-				if owner.UID == uid {
-					resources = append(resources, &lst.Items[idx])
-				}
+		for idx := range lst.Items {
+			item := &lst.Items[idx]
+			isOwned, err := owners.isOwnedBy(item.GetOwnerReferences(), uid, depth)
+			if err != nil {
+				return nil, err
+			}
+			if isOwned {
+				owned = append(owned, detectedResource{obj: item, association: ownershipAssociation})
+			} else if selector != nil && selector.Matches(labels.Set(item.GetLabels())) {
+				labeled = append(labeled, detectedResource{obj: item, association: labelsAssociation})
 			}
 		}
 	}
-	return resources, nil
+	return append(owned, labeled...), nil
 }
 
 func buildOwnedResourceContext(
@@ -214,6 +327,7 @@ func buildOwnedResourceContext(
 	obj *unstructured.Unstructured,
 	ownerEnvVarPrefix *string,
 	restMapper meta.RESTMapper,
+	accessChecker binding.AccessChecker,
 	inputPath string,
 	outputPath string,
 ) (*serviceContext, error) {
@@ -226,25 +340,36 @@ func buildOwnedResourceContext(
 		ownerEnvVarPrefix,
 		restMapper,
 		nil,
+		readinessGate{},
+		accessChecker,
 	)
 	if err != nil {
 		return nil, err
 	}
+	// owned resources are tracked, so changes on them trigger a new reconciliation
+	svcCtx.references = append(svcCtx.references, binding.ObjectReference{
+		GroupVersionKind: obj.GroupVersionKind(),
+		Namespace:        obj.GetNamespace(),
+		Name:             obj.GetName(),
+	})
 	svcCtx.envVars, _, err = nested.GetValue(obj.Object, inputPath, outputPath)
 	return svcCtx, err
 }
 
 func buildOwnedResourceContexts(
 	client dynamic.Interface,
-	objs []*unstructured.Unstructured,
+	resources []detectedResource,
 	ownerEnvVarPrefix *string,
 	restMapper meta.RESTMapper,
+	accessChecker binding.AccessChecker,
 ) ([]*serviceContext, error) {
 	ctxs := make(serviceContextList, 0)
 
-	for _, obj := range objs {
-		for _, br := range bindableResources {
-			if br.gvk != obj.GetObjectKind().GroupVersionKind() {
+	for _, r := range resources {
+		obj := r.obj
+		gvk := obj.GroupVersionKind()
+		for _, br := range bindableResources.list() {
+			if br.gvk != gvk {
 				continue
 			}
 			svcCtx, err := buildOwnedResourceContext(
@@ -252,12 +377,23 @@ func buildOwnedResourceContexts(
 				obj,
 				ownerEnvVarPrefix,
 				restMapper,
+				accessChecker,
 				br.inputPath,
 				br.outputPath,
 			)
 			if err != nil {
 				return nil, err
 			}
+			svcCtx.detectedResource = &v1alpha1.DetectedResource{
+				GroupVersionKind: metav1.GroupVersionKind{
+					Group:   gvk.Group,
+					Version: gvk.Version,
+					Kind:    gvk.Kind,
+				},
+				LocalObjectReference: corev1.LocalObjectReference{Name: obj.GetName()},
+				Namespace:            obj.GetNamespace(),
+				Association:          r.association,
+			}
 			ctxs = append(ctxs, svcCtx)
 		}
 	}
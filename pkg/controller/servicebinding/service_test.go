@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 
 	"github.com/redhat-developer/service-binding-operator/pkg/testutils"
@@ -26,7 +27,7 @@ func TestFindServiceNewSpecCSV(t *testing.T) {
 
 	t.Run("golden path", func(t *testing.T) {
 		cr, err := findService(
-			f.FakeDynClient(), ns, db.GetObjectKind().GroupVersionKind(), resourceRef)
+			f.FakeDynClient(), ns, db.GetObjectKind().GroupVersionKind(), resourceRef, testutils.BuildTestRESTMapper())
 		require.NoError(t, err)
 		require.NotNil(t, cr)
 	})
@@ -44,7 +45,7 @@ func TestFindService(t *testing.T) {
 
 	t.Run("missing service namespace", func(t *testing.T) {
 		cr, err := findService(
-			f.FakeDynClient(), "", db.GetObjectKind().GroupVersionKind(), name)
+			f.FakeDynClient(), "", db.GetObjectKind().GroupVersionKind(), name, testutils.BuildTestRESTMapper())
 		require.Error(t, err)
 		require.Equal(t, err, errUnspecifiedBackingServiceNamespace)
 		require.Nil(t, cr)
@@ -52,7 +53,7 @@ func TestFindService(t *testing.T) {
 
 	t.Run("golden path", func(t *testing.T) {
 		cr, err := findService(
-			f.FakeDynClient(), ns, db.GetObjectKind().GroupVersionKind(), name)
+			f.FakeDynClient(), ns, db.GetObjectKind().GroupVersionKind(), name, testutils.BuildTestRESTMapper())
 		require.NoError(t, err)
 		require.NotNil(t, cr)
 	})
@@ -76,6 +77,7 @@ func TestPlannerWithExplicitBackingServiceNamespace(t *testing.T) {
 			backingServiceNamespace,
 			db.GetObjectKind().GroupVersionKind(),
 			name,
+			testutils.BuildTestRESTMapper(),
 		)
 		require.NoError(t, err)
 		require.NotNil(t, cr)
@@ -89,11 +91,66 @@ func TestFindServiceCRD(t *testing.T) {
 	cr := f.AddMockedDatabaseCR("database", ns)
 
 	t.Run("golden path", func(t *testing.T) {
-		crd, err := findServiceCRD(f.FakeDynClient(), cr.GetObjectKind().GroupVersionKind())
+		crd, err := findServiceCRD(f.FakeDynClient(), cr.GetObjectKind().GroupVersionKind(), testutils.BuildTestRESTMapper())
 		require.NoError(t, err)
 		require.NotNil(t, crd)
 		require.Equal(t, expected, crd)
 	})
+
+	t.Run("v1 CRD first", func(t *testing.T) {
+		f := mocks.NewFake(t, ns)
+		f.AddMockedUnstructuredDatabaseCRD()
+		expected := f.AddMockedUnstructuredDatabaseCRDV1()
+		crd, err := findServiceCRD(f.FakeDynClient(), cr.GetObjectKind().GroupVersionKind(), testutils.BuildTestRESTMapper())
+		require.NoError(t, err)
+		require.Equal(t, expected, crd)
+	})
+}
+
+func TestFindServiceCRDWithIrregularPlural(t *testing.T) {
+	ns := "planner"
+	f := mocks.NewFake(t, ns)
+	expected := f.AddMockedUnstructuredDatabaseCRDV1()
+	expected.SetName("dbinstances." + mocks.CRDName)
+	cr := f.AddMockedDatabaseCR("database", ns)
+
+	// the plural is resolved through the RESTMapper, rather than guessed from the kind
+	gvk := cr.GetObjectKind().GroupVersionKind()
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.AddSpecific(
+		gvk,
+		gvk.GroupVersion().WithResource("dbinstances"),
+		gvk.GroupVersion().WithResource("dbinstance"),
+		meta.RESTScopeNamespace,
+	)
+	crd, err := findServiceCRD(f.FakeDynClient(), gvk, restMapper)
+	require.NoError(t, err)
+	require.Equal(t, expected, crd)
+}
+
+func TestCRDAnnotations(t *testing.T) {
+	crd, err := mocks.UnstructuredDatabaseCRDV1Mock("")
+	require.NoError(t, err)
+	crd.SetAnnotations(map[string]string{
+		"service.binding/username":          "path={.status.username}",
+		"service.binding/dbName":            "path={.spec.db}",
+		"v1alpha1.service.binding/dbName":   "path={.spec.dbName}",
+		"v1beta1.service.binding/password":  "path={.status.password}",
+		"v2alpha10.service.binding/replica": "path={.status.replica}",
+		"vendor.example.com/other":          "value",
+	})
+
+	require.Equal(t, map[string]string{
+		"service.binding/username": "path={.status.username}",
+		"service.binding/dbName":   "path={.spec.dbName}",
+		"vendor.example.com/other": "value",
+	}, crdAnnotations(crd, "v1alpha1"))
+	require.Equal(t, map[string]string{
+		"service.binding/username": "path={.status.username}",
+		"service.binding/dbName":   "path={.spec.db}",
+		"service.binding/password": "path={.status.password}",
+		"vendor.example.com/other": "value",
+	}, crdAnnotations(crd, "v1beta1"))
 }
 
 func TestGetObjectType(t *testing.T) {
@@ -228,6 +285,7 @@ func TestBuildOwnerResourceContext(t *testing.T) {
 			obj,
 			tc.ownerEnvVarPrefix,
 			testutils.BuildTestRESTMapper(),
+			nil,
 			tc.inputPath,
 			tc.outputPath,
 		)
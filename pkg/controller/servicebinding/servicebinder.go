@@ -2,8 +2,10 @@ package servicebinding
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
 	"gotest.tools/assert/cmp"
@@ -13,12 +15,16 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/config"
 	"github.com/redhat-developer/service-binding-operator/pkg/converter"
 	"github.com/redhat-developer/service-binding-operator/pkg/log"
+	"github.com/redhat-developer/service-binding-operator/pkg/tracing"
 )
 
 const (
@@ -26,15 +32,8 @@ const (
 	bindingFail = "BindingFail"
 	//finalizer annotation used in finalizer steps
 	finalizer = "finalizer.servicebinding.openshift.io"
-	// time in seconds to wait before requeuing requests
-	requeueAfter int64 = 45
 )
 
-// defaultPathToContainers has the logical path logical path
-// to find containers on supported objects
-// Used as []string{"spec", "template", "spec", "containers"}
-const defaultPathToContainers = "spec.template.spec.containers"
-
 // groupVersion represents the service binding request resource's group version.
 var groupVersion = v1alpha1.SchemeGroupVersion.WithResource(serviceBindingRequestResource)
 
@@ -47,11 +46,13 @@ func (b *serviceBinder) message(err error) string {
 type serviceBinderOptions struct {
 	logger                 *log.Log
 	dynClient              dynamic.Interface
+	secretClient           dynamic.Interface // intermediary secret client, dynClient when unset
 	detectBindingResources bool
 	sbr                    *v1alpha1.ServiceBinding
 	objects                []*unstructured.Unstructured
 	binding                *internalBinding
 	restMapper             meta.RESTMapper
+	recorder               record.EventRecorder
 }
 
 // errInvalidServiceBinderOptions is returned when ServiceBinderOptions contains an invalid value.
@@ -98,15 +99,33 @@ type serviceBinder struct {
 	sbr *v1alpha1.ServiceBinding
 	// secret is the secret associated with the Service Binding.
 	secret *secret
+	// annotationErrors contains the binding annotations which couldn't be processed.
+	annotationErrors []v1alpha1.AnnotationError
+	// detectedResources contains the resources related to the services which contributed data.
+	detectedResources []v1alpha1.DetectedResource
+	// recorder emits events on the Service Binding.
+	recorder record.EventRecorder
+	// ctx is the request context, carrying the reconcile span.
+	ctx context.Context
 }
 
-// updateServiceBinding execute update API call on a SBR request. It can return errors from
-// this action.
+// updateServiceBinding patches the finalizers of a SBR request. Only the finalizers are written, so
+// defaults merged into the spec while reconciling aren't persisted. It can return errors from this
+// action.
 func updateServiceBinding(
 	dynClient dynamic.Interface,
 	sbr *v1alpha1.ServiceBinding,
 ) (*v1alpha1.ServiceBinding, error) {
-	u, err := converter.ToUnstructured(sbr)
+	finalizers := sbr.GetFinalizers()
+	if finalizers == nil {
+		finalizers = []string{}
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"finalizers":      finalizers,
+			"resourceVersion": sbr.GetResourceVersion(),
+		},
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +134,7 @@ func updateServiceBinding(
 		Resource(groupVersion).
 		Namespace(sbr.GetNamespace())
 
-	u, err = nsClient.Update(u, v1.UpdateOptions{})
+	u, err := nsClient.Patch(sbr.GetName(), types.MergePatchType, patch, v1.PatchOptions{})
 
 	if err != nil {
 		return nil, err
@@ -129,8 +148,8 @@ func updateServiceBinding(
 	return sbr, nil
 }
 
-// updateServiceBinding execute update API call on a SBR request. It can return errors from
-// this action.
+// updateServiceBinding patches the finalizers of a SBR request. It can return errors from this
+// action.
 func (b *serviceBinder) updateServiceBinding(
 	sbr *v1alpha1.ServiceBinding,
 ) (*v1alpha1.ServiceBinding, error) {
@@ -148,12 +167,17 @@ func (b *serviceBinder) unbind() (reconcile.Result, error) {
 	}
 
 	logger.Info("Cleaning related objects from operator's annotations...")
-	if err := removeAndUpdateSBRAnnotations(b.dynClient, b.objects); err != nil {
+	if err := removeAndUpdateSBRAnnotations(b.dynClient, b.objects, b.binder.restMapper); err != nil {
 		logger.Error(err, "On removing annotations from related objects.")
 		return requeueError(err)
 	}
 
-	if err := b.binder.unbind(); err != nil {
+	injectionStart := time.Now()
+	injectionCtx, injectionSpan := tracing.StartSpan(b.ctx, injectionStage)
+	err := b.binder.withContext(injectionCtx).unbind()
+	observeStageDuration(injectionStage, injectionStart)
+	tracing.EndSpan(injectionSpan, err)
+	if err != nil {
 		logger.Error(err, "On unbinding related objects")
 		return requeueError(err)
 	}
@@ -192,6 +216,7 @@ func updateServiceBindingStatus(
 	if err != nil {
 		return nil, err
 	}
+	bindingConditions.observe(sbr)
 
 	err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, sbr)
 	if err != nil {
@@ -220,6 +245,15 @@ func (b *serviceBinder) updateStatusServiceBinding(
 	return updateServiceBindingStatus(b.dynClient, sbr)
 }
 
+// injectionFailureReason returns the InjectionReady condition reason for the given error.
+func injectionFailureReason(err error) string {
+	var modifierErr *ExtraFieldsModifierError
+	if errors.As(err, &modifierErr) {
+		return ExtraFieldsModifierFailedReason
+	}
+	return bindingFail
+}
+
 // onError comprise the update of ServiceBinding status to set error flag, and inspect
 // informed error to apply a different behavior for not-founds.
 func (b *serviceBinder) onError(
@@ -232,10 +266,11 @@ func (b *serviceBinder) onError(
 	if objs != nil {
 		b.setApplicationObjects(sbrStatus, objs)
 	}
+	recordEvent(b.recorder, sbr, corev1.EventTypeWarning, injectionFailureReason(err), b.message(err))
 	conditionsv1.SetStatusCondition(&sbrStatus.Conditions, conditionsv1.Condition{
 		Type:    InjectionReady,
 		Status:  corev1.ConditionFalse,
-		Reason:  bindingFail,
+		Reason:  injectionFailureReason(err),
 		Message: b.message(err),
 	})
 	conditionsv1.SetStatusCondition(&sbrStatus.Conditions, conditionsv1.Condition{
@@ -248,7 +283,7 @@ func (b *serviceBinder) onError(
 	}
 	b.sbr = newSbr
 
-	return requeueOnNotFound(err, requeueAfter)
+	return requeueOnNotFound(err, config.Get().RequeueAfter)
 }
 
 // isApplicationEmpty returns true if application is not declared in
@@ -301,15 +336,31 @@ func (b *serviceBinder) handleApplicationError(applicationError error, sbrStatus
 // bind configures binding between the Service Binding and its related objects.
 func (b *serviceBinder) bind() (reconcile.Result, error) {
 	sbrStatus := b.sbr.Status.DeepCopy()
+	sbrStatus.AnnotationErrors = b.annotationErrors
+	sbrStatus.DetectedResources = b.detectedResources
 
 	b.logger.Debug("Saving data on intermediary secret...")
 
-	secretObj, err := b.secret.createOrUpdate(b.envVars, b.sbr.AsOwnerReference())
+	secretStart := time.Now()
+	secretCtx, secretSpan := tracing.StartSpan(b.ctx, secretWriteStage)
+	secretObj, op, err := b.secret.withContext(secretCtx).
+		createOrUpdate(b.envVars, b.sbr.AsOwnerReference())
+	observeStageDuration(secretWriteStage, secretStart)
+	secretSpan.SetAttributes(secretOperationKey.String(string(op)))
+	tracing.EndSpan(secretSpan, err)
 	if err != nil {
 		b.logger.Error(err, "On saving secret data..")
 		return b.onError(err, b.sbr, sbrStatus, nil)
 	}
 	sbrStatus.Secret = secretObj.GetName()
+	switch op {
+	case secretCreated:
+		recordEvent(b.recorder, b.sbr, corev1.EventTypeNormal, SecretCreatedReason,
+			"Created secret %q", secretObj.GetName())
+	case secretUpdated:
+		recordEvent(b.recorder, b.sbr, corev1.EventTypeNormal, SecretUpdatedReason,
+			"Updated secret %q", secretObj.GetName())
+	}
 
 	conditionsv1.SetStatusCondition(&sbrStatus.Conditions, conditionsv1.Condition{
 		Type:   CollectionReady,
@@ -329,10 +380,19 @@ func (b *serviceBinder) bind() (reconcile.Result, error) {
 		})
 		return b.handleApplicationError(errEmptyApplication, sbrStatus)
 	}
-	updatedObjects, err := b.binder.bind()
+	injectionStart := time.Now()
+	injectionCtx, injectionSpan := tracing.StartSpan(b.ctx, injectionStage)
+	appBinder := b.binder.withContext(injectionCtx)
+	appBinder.secretResourceVersion = secretObj.GetResourceVersion()
+	updatedObjects, err := appBinder.bind()
+	observeStageDuration(injectionStage, injectionStart)
+	injectionSpan.SetAttributes(applicationsKey.StringSlice(applicationRefs(updatedObjects)))
+	tracing.EndSpan(injectionSpan, err)
 	if err != nil {
 		b.logger.Error(err, "On binding application.")
 		if errors.Is(err, errApplicationNotFound) {
+			recordEvent(b.recorder, b.sbr, corev1.EventTypeWarning, ApplicationNotFoundReason,
+				errApplicationNotFound.Error())
 			conditionsv1.SetStatusCondition(&sbrStatus.Conditions, conditionsv1.Condition{
 				Type:    InjectionReady,
 				Status:  corev1.ConditionFalse,
@@ -398,8 +458,10 @@ func (b *serviceBinder) setApplicationObjects(
 	sbrStatus.Applications = boundApps
 }
 
-// set default value for application selector
+// set default value for application selector; containers are found at the default containers path
+// of the operator configuration, e.g. "spec.template.spec.containers"
 func ensureDefaults(applicationSelector *v1alpha1.Application) {
+	defaultPathToContainers := config.Get().DefaultContainersPath
 	if applicationSelector != nil {
 		if applicationSelector.LabelSelector == nil {
 			applicationSelector.LabelSelector = &metav1.LabelSelector{}
@@ -430,10 +492,16 @@ func buildServiceBinder(
 		return nil, err
 	}
 
+	// the intermediary secret isn't read from the informer cache, since updates based on a stale
+	// copy would be skipped or rejected
+	secretClient := options.secretClient
+	if secretClient == nil {
+		secretClient = options.dynClient
+	}
 	// FIXME(isuttonl): review whether it is possible to move Secret.Commit() and Secret.Delete() to
 	// ServiceBinder.
 	secret := newSecret(
-		options.dynClient,
+		secretClient,
 		options.sbr.GetNamespace(),
 		options.sbr.GetName(),
 	)
@@ -447,23 +515,30 @@ func buildServiceBinder(
 		options.binding.volumeKeys,
 		options.restMapper,
 	)
+	binder.recorder = options.recorder
 
 	ensureDefaults(options.sbr.Spec.Application)
 
 	return &serviceBinder{
-		logger:    options.logger,
-		binder:    binder,
-		dynClient: options.dynClient,
-		sbr:       options.sbr,
-		objects:   options.objects,
-		envVars:   options.binding.envVars,
-		secret:    secret,
+		logger:            options.logger,
+		binder:            binder,
+		dynClient:         options.dynClient,
+		sbr:               options.sbr,
+		objects:           options.objects,
+		envVars:           options.binding.envVars,
+		secret:            secret,
+		annotationErrors:  options.binding.annotationErrors,
+		detectedResources: options.binding.detectedResources,
+		recorder:          options.recorder,
+		ctx:               ctx,
 	}, nil
 }
 
 type internalBinding struct {
-	envVars    map[string][]byte
-	volumeKeys []string
+	envVars           map[string][]byte
+	volumeKeys        []string
+	annotationErrors  []v1alpha1.AnnotationError
+	detectedResources []v1alpha1.DetectedResource
 }
 
 func buildBinding(
@@ -479,7 +554,9 @@ func buildBinding(
 	}
 
 	return &internalBinding{
-		envVars:    envVars,
-		volumeKeys: volumeKeys,
+		envVars:           envVars,
+		volumeKeys:        volumeKeys,
+		annotationErrors:  svcCtxs.getAnnotationErrors(),
+		detectedResources: svcCtxs.getDetectedResources(),
 	}, nil
 }
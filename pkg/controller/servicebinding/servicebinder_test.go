@@ -707,3 +707,28 @@ func TestEnsureDefaults(t *testing.T) {
 	})
 
 }
+
+// TestUpdateServiceBinding checks only the finalizers are written, leaving out the defaults merged
+// into the spec while reconciling.
+func TestUpdateServiceBinding(t *testing.T) {
+	f := mocks.NewFake(t, reconcilerNs)
+	f.AddMockedUnstructuredServiceBinding(reconcilerName, "backingServiceRef", "applicationRef", deploymentsGVR, nil)
+	fakeDynClient := f.FakeDynClient()
+
+	u, err := fakeDynClient.Resource(groupVersion).Namespace(reconcilerNs).Get(reconcilerName, metav1.GetOptions{})
+	require.NoError(t, err)
+	sbr := &v1alpha1.ServiceBinding{}
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, sbr))
+
+	sbr.Spec.EnvVarPrefix = "TEAM"
+	addFinalizer(sbr)
+	updated, err := updateServiceBinding(fakeDynClient, sbr)
+	require.NoError(t, err)
+	require.Equal(t, []string{finalizer}, updated.GetFinalizers())
+	require.Empty(t, updated.Spec.EnvVarPrefix)
+
+	removeFinalizer(updated)
+	updated, err = updateServiceBinding(fakeDynClient, updated)
+	require.NoError(t, err)
+	require.Empty(t, updated.GetFinalizers())
+}
@@ -1,11 +1,15 @@
 package servicebinding
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/imdario/mergo"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -28,11 +32,65 @@ type serviceContext struct {
 	envVarPrefix *string
 	// Id indicates a name the service can be referred in custom environment variables.
 	id *string
+	// references contains the objects, other than the service, read by the service's annotations.
+	references []binding.ObjectReference
+	// annotationErrors contains the binding annotations which couldn't be processed.
+	annotationErrors []v1alpha1.AnnotationError
+	// missingRequired contains the required binding annotations which values couldn't be found.
+	missingRequired []v1alpha1.AnnotationError
+	// detectedResource describes the resource when detected as related to a service.
+	detectedResource *v1alpha1.DetectedResource
+	// direct tells whether the service is a Secret or ConfigMap whose entries are all bound.
+	direct bool
 }
 
 // serviceContextList is a list of ServiceContext values.
 type serviceContextList []*serviceContext
 
+// getReferences returns the objects referenced by the services contained in the collection.
+func (sc serviceContextList) getReferences() []binding.ObjectReference {
+	var refs []binding.ObjectReference
+	for _, s := range sc {
+		refs = append(refs, s.references...)
+	}
+	return refs
+}
+
+// getAnnotationErrors returns the annotation errors found processing the services contained in the
+// collection.
+func (sc serviceContextList) getAnnotationErrors() []v1alpha1.AnnotationError {
+	var annErrs []v1alpha1.AnnotationError
+	for _, s := range sc {
+		annErrs = append(annErrs, s.annotationErrors...)
+	}
+	return annErrs
+}
+
+// getMissingRequired returns the required annotations which values couldn't be found in the services
+// contained in the collection.
+func (sc serviceContextList) getMissingRequired() []v1alpha1.AnnotationError {
+	var missing []v1alpha1.AnnotationError
+	for _, s := range sc {
+		missing = append(missing, s.missingRequired...)
+	}
+	return missing
+}
+
+// getDetectedResources returns the detected resources which contributed binding data, each listed
+// once.
+func (sc serviceContextList) getDetectedResources() []v1alpha1.DetectedResource {
+	var detected []v1alpha1.DetectedResource
+	seen := make(map[v1alpha1.DetectedResource]bool)
+	for _, s := range sc {
+		if s.detectedResource == nil || len(s.envVars) == 0 || seen[*s.detectedResource] {
+			continue
+		}
+		seen[*s.detectedResource] = true
+		detected = append(detected, *s.detectedResource)
+	}
+	return detected
+}
+
 // getServices returns a slice of service unstructured objects contained in the collection.
 func (sc serviceContextList) getServices() []*unstructured.Unstructured {
 	var crs []*unstructured.Unstructured
@@ -49,58 +107,170 @@ func stringValueOrDefault(val *string, defaultVal string) string {
 	return defaultVal
 }
 
+// serviceResolution is the outcome of resolving a service selector.
+type serviceResolution struct {
+	selector  v1alpha1.Service
+	namespace string
+	// err is the error resolving the service, if any.
+	err error
+}
+
+// optional returns whether the service is optional.
+func (r serviceResolution) optional() bool {
+	return r.selector.Optional != nil && *r.selector.Optional
+}
+
+// status returns the ServiceStatus describing the resolution.
+func (r serviceResolution) status() v1alpha1.ServiceStatus {
+	st := v1alpha1.ServiceStatus{
+		GroupVersionKind:     r.selector.GroupVersionKind,
+		LocalObjectReference: r.selector.LocalObjectReference,
+		Namespace:            r.namespace,
+		Optional:             r.optional(),
+		State:                v1alpha1.ServiceResolved,
+	}
+	switch {
+	case r.err == nil:
+		return st
+	case errors.IsNotFound(r.err) || meta.IsNoMatchError(r.err):
+		st.State = v1alpha1.ServiceMissing
+	case isErrServiceNotReady(r.err):
+		st.State = v1alpha1.ServiceNotReady
+	default:
+		st.State = v1alpha1.ServiceError
+	}
+	st.Message = r.err.Error()
+	return st
+}
+
+// serviceResolutionList is a list of serviceResolution values.
+type serviceResolutionList []serviceResolution
+
+// statuses returns the ServiceStatus describing each resolution.
+func (rs serviceResolutionList) statuses() []v1alpha1.ServiceStatus {
+	statuses := make([]v1alpha1.ServiceStatus, 0, len(rs))
+	for _, r := range rs {
+		statuses = append(statuses, r.status())
+	}
+	return statuses
+}
+
+// skipped returns the optional services which couldn't be resolved.
+func (rs serviceResolutionList) skipped() serviceResolutionList {
+	var skipped serviceResolutionList
+	for _, r := range rs {
+		if r.err != nil && r.optional() {
+			skipped = append(skipped, r)
+		}
+	}
+	return skipped
+}
+
 // buildServiceContexts return a collection of ServiceContext values from the given service
-// selectors.
+// selectors, and the resolution of each selector. Optional services which can't be resolved are
+// skipped; otherwise the error resolving the first service which failed is returned, once all the
+// selectors are resolved.
 func buildServiceContexts(
 	logger *log.Log,
 	client dynamic.Interface,
 	defaultNs string,
 	selectors []v1alpha1.Service,
 	includeServiceOwnedResources *bool,
+	detection *v1alpha1.BindingResourcesDetection,
 	restMapper meta.RESTMapper,
-) (serviceContextList, error) {
+) (serviceContextList, serviceResolutionList, error) {
 	svcCtxs := make(serviceContextList, 0)
+	resolutions := make(serviceResolutionList, 0, len(selectors))
+	var firstErr error
+	// objects referenced from other namespaces are read on behalf of the ServiceBinding's namespace
+	accessChecker := binding.NewSubjectAccessChecker(client, defaultNs)
 
-SELECTORS:
 	for _, s := range selectors {
 		ns := stringValueOrDefault(s.Namespace, defaultNs)
-		gvk := schema.GroupVersionKind{Kind: s.Kind, Version: s.Version, Group: s.Group}
-		svcCtx, err := buildServiceContext(logger.WithName("buildServiceContexts"), client, ns, gvk,
-			s.Name, s.EnvVarPrefix, restMapper, s.Id)
+		ctxs, err := buildSelectorContexts(logger, client, ns, defaultNs, s,
+			includeServiceOwnedResources, detection, restMapper, accessChecker)
+		resolution := serviceResolution{selector: s, namespace: ns, err: err}
+		resolutions = append(resolutions, resolution)
+		switch {
+		case err == nil:
+			svcCtxs = append(svcCtxs, ctxs...)
+		case resolution.optional():
+			logger.Info("Skipping optional service", "Kind", s.Kind, "Name", s.Name, "Error", err.Error())
+		case firstErr == nil:
+			firstErr = err
+		}
+	}
+
+	if firstErr != nil {
+		return nil, resolutions, firstErr
+	}
+	return svcCtxs, resolutions, nil
+}
+
+// buildSelectorContexts returns the ServiceContext values of the service matching the given
+// selector, and of the resources it owns when requested. A Secret or ConfigMap whose entries are all
+// bound is rejected when it lives in a namespace other than the ServiceBinding's, unless the
+// ServiceBinding's namespace is granted access to it.
+func buildSelectorContexts(
+	logger *log.Log,
+	client dynamic.Interface,
+	ns string,
+	bindingNs string,
+	s v1alpha1.Service,
+	includeServiceOwnedResources *bool,
+	detection *v1alpha1.BindingResourcesDetection,
+	restMapper meta.RESTMapper,
+	accessChecker binding.AccessChecker,
+) (serviceContextList, error) {
+	gvk := schema.GroupVersionKind{Kind: s.Kind, Version: s.Version, Group: s.Group}
+	svcCtx, err := buildServiceContext(logger.WithName("buildServiceContexts"), client, ns, gvk,
+		s.Name, s.EnvVarPrefix, restMapper, s.Id, newReadinessGate(s.ReadyCondition, s.ReadyPath),
+		accessChecker)
 
+	if err != nil {
+		// best effort approach; should not break in common cases such as a unknown annotation
+		// prefix (other annotations might exist in the resource) or, in the case of a valid
+		// annotation, the handler expected for the annotation can't be found.
+		if binding.IsErrEmptyAnnotationName(err) || binding.IsErrHandlerNotFound(err) {
+			logger.Trace("Continuing to next selector", "Error", err)
+			return nil, nil
+		}
+		return nil, err
+	}
+	if svcCtx.direct {
+		mapping, err := restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 		if err != nil {
-			// best effort approach; should not break in common cases such as a unknown annotation
-			// prefix (other annotations might exist in the resource) or, in the case of a valid
-			// annotation, the handler expected for the annotation can't be found.
-			if binding.IsErrEmptyAnnotationName(err) || binding.IsErrHandlerNotFound(err) {
-				logger.Trace("Continuing to next selector", "Error", err)
-				continue SELECTORS
-			}
 			return nil, err
 		}
-		svcCtxs = append(svcCtxs, svcCtx)
+		err = binding.CheckAccess(accessChecker, bindingNs, mapping.Resource, ns, s.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	svcCtxs := serviceContextList{svcCtx}
 
-		if includeServiceOwnedResources != nil && *includeServiceOwnedResources {
-			// use the selector's kind as owned resources environment variable prefix
-			svcEnvVarPrefix := svcCtx.envVarPrefix
-			if svcEnvVarPrefix == nil {
-				svcEnvVarPrefix = &s.Kind
-			}
-			ownedResourcesCtxs, err := findOwnedResourcesCtxs(
-				logger,
-				client,
-				ns,
-				svcCtx.service.GetName(),
-				svcCtx.service.GetUID(),
-				gvk,
-				svcEnvVarPrefix,
-				restMapper,
-			)
-			if err != nil {
-				return nil, err
-			}
-			svcCtxs = append(svcCtxs, ownedResourcesCtxs...)
+	if includeServiceOwnedResources != nil && *includeServiceOwnedResources {
+		// use the selector's kind as owned resources environment variable prefix
+		svcEnvVarPrefix := svcCtx.envVarPrefix
+		if svcEnvVarPrefix == nil {
+			svcEnvVarPrefix = &s.Kind
+		}
+		ownedResourcesCtxs, err := findOwnedResourcesCtxs(
+			logger,
+			client,
+			ns,
+			svcCtx.service.GetName(),
+			svcCtx.service.GetUID(),
+			gvk,
+			svcEnvVarPrefix,
+			detection,
+			restMapper,
+			accessChecker,
+		)
+		if err != nil {
+			return nil, err
 		}
+		svcCtxs = append(svcCtxs, ownedResourcesCtxs...)
 	}
 
 	return svcCtxs, nil
@@ -114,15 +284,18 @@ func findOwnedResourcesCtxs(
 	uid types.UID,
 	gvk schema.GroupVersionKind,
 	envVarPrefix *string,
+	detection *v1alpha1.BindingResourcesDetection,
 	restMapper meta.RESTMapper,
+	accessChecker binding.AccessChecker,
 ) (serviceContextList, error) {
 	ownedResources, err := getOwnedResources(
 		logger,
 		client,
 		ns,
-		gvk,
 		name,
 		uid,
+		detection,
+		restMapper,
 	)
 	if err != nil {
 		return nil, err
@@ -133,6 +306,7 @@ func findOwnedResourcesCtxs(
 		ownedResources,
 		envVarPrefix,
 		restMapper,
+		accessChecker,
 	)
 }
 
@@ -160,13 +334,16 @@ func runHandler(
 	value string,
 	envVars map[string]interface{},
 	volumeKeys *[]string,
+	references *[]binding.ObjectReference,
 	restMapper meta.RESTMapper,
+	accessChecker binding.AccessChecker,
 ) error {
-	h, err := binding.NewSpecHandler(client, key, value, *obj, restMapper)
+	h, err := binding.NewSpecHandler(client, key, value, *obj, restMapper, accessChecker)
 	if err != nil {
 		return err
 	}
 	r, err := h.Handle()
+	*references = append(*references, h.References()...)
 	if err != nil {
 		return err
 	}
@@ -191,7 +368,8 @@ func runHandler(
 
 // buildServiceContext inspects g the API server searching for the service resources, associated CRD
 // and OLM's CRDDescription if present, and processes those with relevant annotations to compose a
-// ServiceContext.
+// ServiceContext. An errServiceNotReady is returned while the service doesn't pass the readiness
+// gate, completed by the readiness annotations.
 func buildServiceContext(
 	logger *log.Log,
 	client dynamic.Interface,
@@ -201,8 +379,10 @@ func buildServiceContext(
 	envVarPrefix *string,
 	restMapper meta.RESTMapper,
 	id *string,
+	readiness readinessGate,
+	accessChecker binding.AccessChecker,
 ) (*serviceContext, error) {
-	obj, err := findService(client, ns, gvk, name)
+	obj, err := findService(client, ns, gvk, name, restMapper)
 	if err != nil {
 		return nil, err
 	}
@@ -211,7 +391,7 @@ func buildServiceContext(
 
 	// attempt to search the CRD of given gvk and bail out right away if a CRD can't be found; this
 	// means also a CRDDescription can't exist or if it does exist it is not meaningful.
-	crd, err := findServiceCRD(client, gvk)
+	crd, err := findServiceCRD(client, gvk, restMapper)
 	if err != nil && !errors.IsNotFound(err) {
 		return nil, err
 	} else if !errors.IsNotFound(err) {
@@ -226,8 +406,8 @@ func buildServiceContext(
 		if err != nil {
 			return nil, err
 		}
-		// then override collected annotations with CRD annotations
-		err = mergo.Merge(&anns, crd.GetAnnotations(), mergo.WithOverride)
+		// then override collected annotations with CRD annotations applying to the service version
+		err = mergo.Merge(&anns, crdAnnotations(crd, gvk.Version), mergo.WithOverride)
 		if err != nil {
 			return nil, err
 		}
@@ -239,8 +419,16 @@ func buildServiceContext(
 		return nil, err
 	}
 
+	// binding values of a service which isn't ready might be missing or stale
+	if err := readiness.withAnnotations(anns).check(obj); err != nil {
+		return nil, err
+	}
+
 	volumeKeys := make([]string, 0)
 	envVars := make(map[string]interface{})
+	references := make([]binding.ObjectReference, 0)
+	annotationErrors := make([]v1alpha1.AnnotationError, 0)
+	missingRequired := make([]v1alpha1.AnnotationError, 0)
 
 	// outputObj will be used to keep the changes processed by the handler.
 	outputObj := obj.DeepCopy()
@@ -254,20 +442,95 @@ func buildServiceContext(
 
 	for _, k := range keys {
 		v := anns[k]
-		// runHandler modifies 'outputObj', 'envVars' and 'volumeKeys' in place.
-		err := runHandler(client, obj, outputObj, k, v, envVars, &volumeKeys, restMapper)
+		// runHandler modifies 'outputObj', 'envVars', 'volumeKeys' and 'references' in place.
+		err := runHandler(client, obj, outputObj, k, v, envVars, &volumeKeys, &references, restMapper,
+			accessChecker)
 		if err != nil {
 			logger.Debug("Failed executing runHandler", "Error", err)
+			// annotations not meant for binding are also processed, and those shouldn't be reported
+			if !strings.HasPrefix(k, binding.AnnotationPrefix) {
+				continue
+			}
+			annErr := newAnnotationError(obj, k, err)
+			if missing, ok := err.(*binding.ErrMissingValue); ok {
+				if missing.Optional {
+					logger.Debug("Skipping optional annotation", "Key", k)
+					continue
+				}
+				if missing.Required {
+					missingRequired = append(missingRequired, annErr)
+				}
+			}
+			countAnnotationFailure(err)
+			annotationErrors = append(annotationErrors, annErr)
+		}
+	}
+
+	// Secrets and ConfigMaps declaring no binding annotation contribute all their entries, also
+	// exposed decoded to the custom environment variable templates
+	direct := false
+	if !hasBindingAnnotations(anns) {
+		data, ok, err := directServiceData(obj)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			envVars = data
+			outputObj.Object["data"] = data
+			direct = true
 		}
 	}
 
 	serviceCtx := &serviceContext{
-		service:      outputObj,
-		envVars:      envVars,
-		volumeKeys:   volumeKeys,
-		envVarPrefix: envVarPrefix,
-		id:           id,
+		service:          outputObj,
+		envVars:          envVars,
+		volumeKeys:       volumeKeys,
+		envVarPrefix:     envVarPrefix,
+		id:               id,
+		references:       references,
+		annotationErrors: annotationErrors,
+		missingRequired:  missingRequired,
+		direct:           direct,
 	}
 
 	return serviceCtx, nil
 }
+
+// hasBindingAnnotations returns whether the given annotations contain binding annotations.
+func hasBindingAnnotations(anns map[string]string) bool {
+	for k := range anns {
+		if strings.HasPrefix(k, binding.AnnotationPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// errRequiredValueMissing is returned when the values of binding annotations marked as required
+// can't be found.
+type errRequiredValueMissing []v1alpha1.AnnotationError
+
+func (e errRequiredValueMissing) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, annErr := range e {
+		msgs = append(msgs, fmt.Sprintf("%s %q: %s", annErr.Kind, annErr.Name, annErr.Message))
+	}
+	return "required binding values not found: " + strings.Join(msgs, "; ")
+}
+
+// newAnnotationError returns an AnnotationError describing the failure processing the annotation
+// with the given key declared by obj.
+func newAnnotationError(obj *unstructured.Unstructured, key string, err error) v1alpha1.AnnotationError {
+	gvk := obj.GroupVersionKind()
+	return v1alpha1.AnnotationError{
+		GroupVersionKind: metav1.GroupVersionKind{
+			Group:   gvk.Group,
+			Version: gvk.Version,
+			Kind:    gvk.Kind,
+		},
+		LocalObjectReference: corev1.LocalObjectReference{Name: obj.GetName()},
+		Namespace:            obj.GetNamespace(),
+		Key:                  key,
+		Message:              err.Error(),
+	}
+}
@@ -8,6 +8,7 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	pgv1alpha1 "github.com/operator-backing-service-samples/postgresql-operator/pkg/apis/postgresql/v1alpha1"
 	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/controller/servicebinding/binding"
 	"github.com/redhat-developer/service-binding-operator/pkg/log"
 	"github.com/redhat-developer/service-binding-operator/pkg/testutils"
 	"github.com/redhat-developer/service-binding-operator/test/mocks"
@@ -15,6 +16,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestBuildServiceContexts(t *testing.T) {
@@ -25,8 +27,8 @@ func TestBuildServiceContexts(t *testing.T) {
 	t.Run("empty selectors", func(t *testing.T) {
 		ns := "planner"
 		f := mocks.NewFake(t, ns)
-		serviceCtxs, err := buildServiceContexts(
-			logger, f.FakeDynClient(), ns, nil, &falseBool, restMapper)
+		serviceCtxs, _, err := buildServiceContexts(
+			logger, f.FakeDynClient(), ns, nil, &falseBool, nil, restMapper)
 
 		require.NoError(t, err, "buildServiceContexts must execute without errors")
 		require.Empty(t, serviceCtxs, "buildServiceContexts must be empty")
@@ -49,8 +51,8 @@ func TestBuildServiceContexts(t *testing.T) {
 
 		sbr := f.AddMockedServiceBinding(sbrName, nil, firstResourceRef, "", deploymentsGVR, matchLabels)
 
-		serviceCtxs, err := buildServiceContexts(
-			logger, f.FakeDynClient(), firstNamespace, sbr.Spec.Services, &falseBool, restMapper)
+		serviceCtxs, _, err := buildServiceContexts(
+			logger, f.FakeDynClient(), firstNamespace, sbr.Spec.Services, &falseBool, nil, restMapper)
 
 		require.NoError(t, err, "buildServiceContexts must execute without errors")
 		require.Len(t, serviceCtxs, 1, "buildServiceContexts must return only one item")
@@ -69,6 +71,26 @@ func TestBuildServiceContexts(t *testing.T) {
 		require.Equal(t, expectedDbCredentials, gotDbCredentials, "status.dbCredentials in context must be equal to expected")
 	})
 
+	t.Run("v1 CRD with descriptor annotations", func(t *testing.T) {
+		ns := "v1-crd"
+		f := mocks.NewFake(t, ns)
+		f.AddMockedDatabaseCR("db-testing", ns)
+		f.AddMockedUnstructuredDatabaseCRDV1()
+		f.AddNamespacedMockedSecret("db-credentials", ns, nil)
+		sbr := f.AddMockedServiceBinding("service-binding", nil, "db-testing", "", deploymentsGVR, nil)
+
+		serviceCtxs, _, err := buildServiceContexts(
+			logger, f.FakeDynClient(), ns, sbr.Spec.Services, &falseBool, nil, restMapper)
+		require.NoError(t, err)
+		require.Len(t, serviceCtxs, 1)
+		// the Secret comes from the descriptor annotation, dbName from the v1alpha1 annotation
+		require.Equal(t, map[string]interface{}{
+			"username": "user",
+			"password": "password",
+			"dbName":   "test-db",
+		}, serviceCtxs[0].envVars)
+	})
+
 	t.Run("services in different namespace", func(t *testing.T) {
 		sameNs := "same-ns"
 		sameNsResourceRef := "same-ns-database"
@@ -120,8 +142,8 @@ func TestBuildServiceContexts(t *testing.T) {
 			},
 		}
 
-		serviceCtxs, err := buildServiceContexts(
-			logger, f.FakeDynClient(), sameNs, sbr.Spec.Services, &falseBool, restMapper)
+		serviceCtxs, _, err := buildServiceContexts(
+			logger, f.FakeDynClient(), sameNs, sbr.Spec.Services, &falseBool, nil, restMapper)
 
 		require.NoError(t, err, "buildServiceContexts must execute without errors")
 		require.Len(t, serviceCtxs, 2, "buildServiceContexts must return both service contexts")
@@ -154,6 +176,161 @@ func TestBuildServiceContexts(t *testing.T) {
 			require.Equal(t, expectedDbCredentials, gotDbCredentials)
 		}
 	})
+
+	t.Run("annotation errors are reported per key", func(t *testing.T) {
+		ns := "service-ns"
+		f := mocks.NewFake(t, ns)
+		f.AddMockResource(&corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns,
+				Name:      "db-service",
+				Annotations: map[string]string{
+					"service.binding/host":        "path={.data.host}",
+					"service.binding/credentials": "path={.data.secret},objectType=Secret",
+					"unrelated/annotation":        "value",
+				},
+			},
+			Data: map[string]string{
+				"host":   "db.example.com",
+				"secret": "operator-ns/db-credentials",
+			},
+		})
+
+		svcs := []v1alpha1.Service{{
+			GroupVersionKind:     metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+			LocalObjectReference: corev1.LocalObjectReference{Name: "db-service"},
+		}}
+		serviceCtxs, _, err := buildServiceContexts(logger, f.FakeDynClient(), ns, svcs, &falseBool, nil, restMapper)
+		require.NoError(t, err)
+		require.Len(t, serviceCtxs, 1)
+
+		// the fake client doesn't allow reading objects in other namespaces
+		annErrs := serviceCtxs.getAnnotationErrors()
+		require.Len(t, annErrs, 1)
+		require.Equal(t, "service.binding/credentials", annErrs[0].Key)
+		require.Equal(t, "db-service", annErrs[0].Name)
+		require.Equal(t, ns, annErrs[0].Namespace)
+		require.Equal(t, "ConfigMap", annErrs[0].Kind)
+		require.Contains(t, annErrs[0].Message, "access denied")
+		require.Equal(t, "db.example.com", serviceCtxs[0].envVars["host"])
+	})
+
+	t.Run("Secret and ConfigMap services", func(t *testing.T) {
+		ns := "direct"
+		f := mocks.NewFake(t, ns)
+		f.AddNamespacedMockedSecret("db-credentials", ns, nil)
+		configMap := mocks.ConfigMapMock(ns, "db-config")
+		configMap.Data = map[string]string{"host": "db.example.com"}
+		f.AddMockResource(configMap)
+		annotated := mocks.ConfigMapMock(ns, "annotated")
+		annotated.Data = map[string]string{"host": "db.example.com", "port": "5432"}
+		annotated.Annotations = map[string]string{"service.binding/host": "path={.data.host}"}
+		f.AddMockResource(annotated)
+
+		svcs := []v1alpha1.Service{
+			{
+				GroupVersionKind:     metav1.GroupVersionKind{Version: "v1", Kind: "Secret"},
+				LocalObjectReference: corev1.LocalObjectReference{Name: "db-credentials"},
+			},
+			{
+				GroupVersionKind:     metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+				LocalObjectReference: corev1.LocalObjectReference{Name: "db-config"},
+			},
+			{
+				GroupVersionKind:     metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+				LocalObjectReference: corev1.LocalObjectReference{Name: "annotated"},
+			},
+		}
+		serviceCtxs, _, err := buildServiceContexts(logger, f.FakeDynClient(), ns, svcs, &falseBool, nil, restMapper)
+		require.NoError(t, err)
+		require.Len(t, serviceCtxs, 3)
+
+		// Secret values are decoded, and exposed decoded to custom environment variables
+		require.Equal(t, map[string]interface{}{"username": "user", "password": "password"}, serviceCtxs[0].envVars)
+		password, _, err := unstructured.NestedString(serviceCtxs[0].service.Object, "data", "password")
+		require.NoError(t, err)
+		require.Equal(t, "password", password)
+		require.Equal(t, map[string]interface{}{"host": "db.example.com"}, serviceCtxs[1].envVars)
+		// binding annotations select the entries to bind
+		require.Equal(t, map[string]interface{}{"host": "db.example.com"}, serviceCtxs[2].envVars)
+	})
+
+	t.Run("Secret service in another namespace", func(t *testing.T) {
+		ns, otherNs := "tenant", "db"
+		f := mocks.NewFake(t, ns)
+		f.AddNamespacedMockedSecret("db-credentials", otherNs, nil)
+		svcs := []v1alpha1.Service{
+			{
+				GroupVersionKind:     metav1.GroupVersionKind{Version: "v1", Kind: "Secret"},
+				LocalObjectReference: corev1.LocalObjectReference{Name: "db-credentials"},
+				Namespace:            &otherNs,
+			},
+		}
+
+		// the ServiceBinding's namespace is granted access to the Secret only when allowed is set
+		allowed := false
+		client := f.FakeDynClient()
+		client.PrependReactor("create", "subjectaccessreviews",
+			func(action k8stesting.Action) (bool, runtime.Object, error) {
+				u := action.(k8stesting.CreateAction).GetObject().(*unstructured.Unstructured)
+				require.NoError(t, unstructured.SetNestedField(u.Object, allowed, "status", "allowed"))
+				return true, u, nil
+			})
+
+		_, resolutions, err := buildServiceContexts(logger, client, ns, svcs, &falseBool, nil, restMapper)
+		require.Error(t, err)
+		require.IsType(t, &binding.ErrAccessDenied{}, err)
+		require.Equal(t, err, resolutions[0].err)
+
+		allowed = true
+		serviceCtxs, _, err := buildServiceContexts(logger, client, ns, svcs, &falseBool, nil, restMapper)
+		require.NoError(t, err)
+		require.Len(t, serviceCtxs, 1)
+		require.Equal(t, map[string]interface{}{"username": "user", "password": "password"}, serviceCtxs[0].envVars)
+	})
+
+	t.Run("optional services are skipped", func(t *testing.T) {
+		ns := "optional"
+		f := mocks.NewFake(t, ns)
+		f.AddMockResource(&corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "db"},
+		})
+		configMap := func(name string, optional bool) v1alpha1.Service {
+			return v1alpha1.Service{
+				GroupVersionKind:     metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+				LocalObjectReference: corev1.LocalObjectReference{Name: name},
+				Optional:             &optional,
+			}
+		}
+
+		svcs := []v1alpha1.Service{configMap("cache", true), configMap("db", false)}
+		serviceCtxs, resolutions, err := buildServiceContexts(logger, f.FakeDynClient(), ns, svcs, &falseBool, nil, restMapper)
+		require.NoError(t, err)
+		require.Len(t, serviceCtxs, 1)
+		require.Equal(t, "db", serviceCtxs[0].service.GetName())
+		statuses := resolutions.statuses()
+		require.Len(t, statuses, 2)
+		require.Equal(t, v1alpha1.ServiceMissing, statuses[0].State)
+		require.True(t, statuses[0].Optional)
+		require.Equal(t, ns, statuses[0].Namespace)
+		require.NotEmpty(t, statuses[0].Message)
+		require.Equal(t, v1alpha1.ServiceResolved, statuses[1].State)
+		require.Empty(t, statuses[1].Message)
+		require.Len(t, resolutions.skipped(), 1)
+
+		// services following a mandatory service which failed are resolved as well
+		svcs = []v1alpha1.Service{configMap("queue", false), configMap("db", false)}
+		serviceCtxs, resolutions, err = buildServiceContexts(logger, f.FakeDynClient(), ns, svcs, &falseBool, nil, restMapper)
+		require.Error(t, err)
+		require.Empty(t, serviceCtxs)
+		statuses = resolutions.statuses()
+		require.Len(t, statuses, 2)
+		require.Equal(t, v1alpha1.ServiceMissing, statuses[0].State)
+		require.Equal(t, v1alpha1.ServiceResolved, statuses[1].State)
+		require.Empty(t, resolutions.skipped())
+	})
 }
 
 var trueBool = true
@@ -212,7 +389,9 @@ func TestFindOwnedResourcesCtxs_ConfigMap(t *testing.T) {
 			cr.GetUID(),
 			cr.GroupVersionKind(),
 			nil,
+			nil,
 			restMapper,
+			nil,
 		)
 		require.NoError(t, err)
 		require.Len(t, got, 1)
@@ -285,7 +464,9 @@ func TestFindOwnedResourcesCtxs_Secrets(t *testing.T) {
 				cr.GetUID(),
 				cr.GroupVersionKind(),
 				nil,
+				nil,
 				restMapper,
+				nil,
 			)
 			require.NoError(t, err)
 			require.NotEmpty(t, ownedResourcesCtxs)
@@ -0,0 +1,46 @@
+package servicebinding
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/operators/v1alpha1"
+)
+
+const (
+	// serviceBindingNamespaceKey is the namespace of the ServiceBinding being reconciled.
+	serviceBindingNamespaceKey = attribute.Key("servicebinding.namespace")
+	// serviceBindingNameKey is the name of the ServiceBinding being reconciled.
+	serviceBindingNameKey = attribute.Key("servicebinding.name")
+	// servicesKey lists the GVKs of the services declared by the ServiceBinding.
+	servicesKey = attribute.Key("servicebinding.services")
+	// applicationsKey lists the GVKs and names of the application workloads updated.
+	applicationsKey = attribute.Key("servicebinding.applications")
+	// secretOperationKey is the change applied to the intermediate secret.
+	secretOperationKey = attribute.Key("servicebinding.secret.operation")
+	// requeueKey informs whether the ServiceBinding has been requeued.
+	requeueKey = attribute.Key("reconcile.requeue")
+)
+
+// serviceGVKs returns the GVKs of the given services.
+func serviceGVKs(services []v1alpha1.Service) []string {
+	gvks := make([]string, 0, len(services))
+	for _, s := range services {
+		gvks = append(gvks, schema.GroupVersionKind{
+			Group:   s.Group,
+			Version: s.Version,
+			Kind:    s.Kind,
+		}.String())
+	}
+	return gvks
+}
+
+// applicationRefs returns the GVKs and names of the given application workloads.
+func applicationRefs(objs []*unstructured.Unstructured) []string {
+	refs := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		refs = append(refs, obj.GroupVersionKind().String()+" "+obj.GetName())
+	}
+	return refs
+}
@@ -0,0 +1,70 @@
+package servicebinding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/testutils"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+func TestReconcilerTracing(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+	defer otel.SetTracerProvider(previous)
+
+	backingServiceResourceRef := "test-tracing"
+	matchLabels := map[string]string{
+		"connects-to": "database",
+		"environment": "reconciler",
+	}
+
+	f := mocks.NewFake(t, reconcilerNs)
+	f.AddMockedUnstructuredServiceBinding(reconcilerName, backingServiceResourceRef, reconcilerName, deploymentsGVR, matchLabels)
+	f.AddMockedUnstructuredCSV("cluster-service-version-list")
+	f.AddMockedUnstructuredDatabaseCRD()
+	f.AddMockedUnstructuredDatabaseCR(backingServiceResourceRef)
+	f.AddMockedUnstructuredDeployment(reconcilerName, matchLabels)
+	f.AddMockedUnstructuredSecret("db-credentials")
+
+	mapper := testutils.BuildTestRESTMapper()
+	r := &reconciler{dynClient: f.FakeDynClient(), restMapper: mapper, scheme: f.S}
+	r.resourceWatcher = newFakeResourceWatcher(mapper)
+
+	_, err := r.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	children := make(map[trace.SpanID][]string)
+	for _, span := range spanRecorder.Ended() {
+		if _, ok := spans[span.Name()]; !ok {
+			spans[span.Name()] = span
+		}
+		children[span.Parent().SpanID()] = append(children[span.Parent().SpanID()], span.Name())
+	}
+
+	reconcileSpan, ok := spans["Reconcile"]
+	require.True(t, ok)
+	require.False(t, reconcileSpan.Parent().IsValid())
+	require.Contains(t, reconcileSpan.Attributes(), serviceBindingNameKey.String(reconcilerName))
+	require.Contains(t, reconcileSpan.Attributes(), serviceBindingNamespaceKey.String(reconcilerNs))
+
+	for _, stage := range []string{serviceCollectionStage, secretWriteStage, injectionStage} {
+		span, ok := spans[stage]
+		require.True(t, ok, "stage %q should be traced", stage)
+		require.Equal(t, reconcileSpan.SpanContext().SpanID(), span.Parent().SpanID())
+		require.NotEmpty(t, children[span.SpanContext().SpanID()], "API calls of stage %q should be traced", stage)
+	}
+
+	// the ServiceBinding itself is read before any stage starts
+	require.Contains(t, children[reconcileSpan.SpanContext().SpanID()], "dynamic.Get")
+	require.Contains(t, spans[secretWriteStage].Attributes(), secretOperationKey.String(string(secretCreated)))
+	require.Contains(t, spans[injectionStage].Attributes(),
+		applicationsKey.StringSlice([]string{"apps/v1, Kind=Deployment " + reconcilerName}))
+}
@@ -12,7 +12,7 @@ var (
 )
 
 // csvToWatcherMapper creates a EventHandler interface to map ClusterServiceVersion objects back to
-// controller and add given GVK to watch list.
+// controller and watch the GVKs they own for as long as they exist.
 type csvToWatcherMapper struct {
 	controller *sbrController
 }
@@ -34,12 +34,11 @@ func (c *csvToWatcherMapper) Map(obj handler.MapObject) []reconcile.Request {
 		return []reconcile.Request{}
 	}
 
-	for _, gvk := range gvks {
-		log.Debug("Adding watch for GVK", "GVK", gvk)
-		err = c.controller.AddWatchForGVK(gvk)
-		if err != nil {
-			log.Error(err, "Failed to create a watch")
-		}
+	// deleted CSVs own no GVKs anymore, releasing their watches
+	log.Debug("Setting watches for GVKs", "GVKs", gvks)
+	err = c.controller.setWatches(watchOwner{kind: csvOwner, NamespacedName: namespacedName}, gvks)
+	if err != nil {
+		log.Error(err, "Failed to create a watch")
 	}
 
 	return []reconcile.Request{}
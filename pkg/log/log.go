@@ -2,6 +2,7 @@ package log
 
 import (
 	"fmt"
+	"sync/atomic"
 
 	"github.com/go-logr/logr"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
@@ -26,10 +27,35 @@ import (
 //
 // while the --zap-level set to 2 is reserved for a finer DEBUG-level logging
 // provided by the log.Trace() function.
+//
+// SetVerbosity further caps the logged levels at runtime, without going beyond --zap-level.
 type Log struct {
 	log *logr.Logger //log instance
 }
 
+const (
+	// InfoVerbosity logs warnings and informational messages.
+	InfoVerbosity = 0
+	// DebugVerbosity logs debug messages as well.
+	DebugVerbosity = 1
+	// TraceVerbosity logs trace messages as well.
+	TraceVerbosity = 2
+)
+
+// verbosity is the most verbose level logged.
+var verbosity int32 = TraceVerbosity
+
+// SetVerbosity sets the most verbose level logged; messages above it are dropped even when
+// --zap-level enables them.
+func SetVerbosity(v int) {
+	atomic.StoreInt32(&verbosity, int32(v))
+}
+
+// enabled returns whether messages of the given level are logged.
+func (l *Log) enabled(v int) bool {
+	return int(atomic.LoadInt32(&verbosity)) >= v && (*l.log).V(v).Enabled()
+}
+
 // Error logs the message using go-logr package on a default level as ERROR
 func (l *Log) Error(err error, msg string, keysAndValues ...interface{}) {
 	(*l.log).Error(err, msg, keysAndValues...)
@@ -51,14 +77,14 @@ func (l *Log) Info(msg string, keysAndValues ...interface{}) {
 
 // Debug logs the message using go-logr package on a V=1 level as DEBUG
 func (l *Log) Debug(msg string, keysAndValues ...interface{}) {
-	if (*l.log).V(1).Enabled() {
+	if l.enabled(DebugVerbosity) {
 		(*l.log).V(1).Info(msg, keysAndValues...)
 	}
 }
 
 // Trace logs the message using go-logr package on a V=1 level as TRACE
 func (l *Log) Trace(msg string, keysAndValues ...interface{}) {
-	if (*l.log).V(2).Enabled() {
+	if l.enabled(TraceVerbosity) {
 		// The V(1) level here is intentional:
 		//   sTRACE = finer DEBUG logging but is only enabled by setting V=2
 		(*l.log).V(1).Info(fmt.Sprintf("TRACE: %s", msg), keysAndValues...)
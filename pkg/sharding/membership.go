@@ -0,0 +1,284 @@
+package sharding
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/log"
+)
+
+// GroupLabel labels the leases of the replicas sharing namespaces, with the name of their group.
+const GroupLabel = "operators.coreos.com/shard-group"
+
+var (
+	shardingLog = log.NewLog("sharding")
+
+	leasesResource = coordinationv1.SchemeGroupVersion.WithResource("leases")
+)
+
+// Membership keeps the lease of an operator replica in a group renewed, and assigns namespaces to
+// the group members holding a lease which hasn't expired. Members agree on the namespaces each of
+// them owns as long as they see the same leases; a change of members moves only the namespaces
+// of the members joining or leaving. A replica which failed to renew its lease for longer than the
+// lease duration, whose namespaces the other members took over, owns no namespace until renewed.
+type Membership struct {
+	client        dynamic.Interface
+	ns            string
+	group         string
+	identity      string
+	leaseDuration time.Duration
+	// now returns the current time.
+	now func() time.Time
+
+	mu       sync.RWMutex // guards the fields below
+	ring     *Ring
+	onChange []func()
+	// renewed is when the replica lease was last renewed.
+	renewed time.Time
+}
+
+// NewMembership returns the Membership of the given replica in the group, whose leases are held
+// in the given namespace for the given duration.
+func NewMembership(
+	client dynamic.Interface,
+	ns string,
+	group string,
+	identity string,
+	leaseDuration time.Duration,
+) *Membership {
+	return &Membership{
+		client:        client,
+		ns:            ns,
+		group:         group,
+		identity:      identity,
+		leaseDuration: leaseDuration,
+		now:           time.Now,
+		ring:          NewRing(nil),
+	}
+}
+
+// leaseName returns the name of the replica lease.
+func (m *Membership) leaseName() string {
+	return fmt.Sprintf("%s-shard-%s", m.group, m.identity)
+}
+
+// OnChange registers a function called whenever the group members change.
+func (m *Membership) OnChange(f func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onChange = append(m.onChange, f)
+}
+
+// Owns returns whether the replica owns the given namespace; cluster scoped objects, which have no
+// namespace, are owned by a single replica as well.
+func (m *Membership) Owns(ns string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.ring.Owner(ns) == m.identity
+}
+
+// Members returns the identities of the group members.
+func (m *Membership) Members() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.ring.Members()
+}
+
+// Join acquires the replica lease and reads the group members; it's meant to be called before the
+// manager starts, so the replica owns its namespaces from the start.
+func (m *Membership) Join() error {
+	if err := m.renew(); err != nil {
+		return err
+	}
+	return m.refresh()
+}
+
+// Start renews the replica lease and refreshes the group members until the given channel is
+// closed, then releases the lease so the other members take over its namespaces right away.
+func (m *Membership) Start(stop <-chan struct{}) error {
+	ticker := time.NewTicker(m.leaseDuration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			err := m.client.Resource(leasesResource).Namespace(m.ns).Delete(m.leaseName(), &metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				shardingLog.Error(err, "on releasing the shard lease")
+			}
+			return nil
+		case <-ticker.C:
+			m.sync()
+		}
+	}
+}
+
+// sync renews the replica lease and refreshes the group members; when the members can't be read,
+// the replica leaves them once its lease expired.
+func (m *Membership) sync() {
+	if err := m.renew(); err != nil {
+		shardingLog.Error(err, "on renewing the shard lease")
+	}
+	if err := m.refresh(); err != nil {
+		shardingLog.Error(err, "on reading the shard members")
+		m.update(m.otherMembers())
+	}
+}
+
+// renew creates or renews the replica lease.
+func (m *Membership) renew() error {
+	now := m.now()
+	if err := m.writeLease(now); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	m.renewed = now
+	m.mu.Unlock()
+	return nil
+}
+
+// writeLease creates or updates the replica lease, renewed at the given time.
+func (m *Membership) writeLease(now time.Time) error {
+	resourceClient := m.client.Resource(leasesResource).Namespace(m.ns)
+	durationSeconds := int32(m.leaseDuration / time.Second)
+	renewTime := metav1.NewMicroTime(now)
+	lease := &coordinationv1.Lease{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: coordinationv1.SchemeGroupVersion.String(),
+			Kind:       "Lease",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.leaseName(),
+			Namespace: m.ns,
+			Labels:    map[string]string{GroupLabel: m.group},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &m.identity,
+			LeaseDurationSeconds: &durationSeconds,
+			RenewTime:            &renewTime,
+		},
+	}
+
+	existing, err := resourceClient.Get(m.leaseName(), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		lease.Spec.AcquireTime = &renewTime
+		u, err := toUnstructured(lease)
+		if err != nil {
+			return err
+		}
+		_, err = resourceClient.Create(u, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	lease.ResourceVersion = existing.GetResourceVersion()
+	lease.Spec.AcquireTime, err = acquireTime(existing)
+	if err != nil {
+		return err
+	}
+	u, err := toUnstructured(lease)
+	if err != nil {
+		return err
+	}
+	_, err = resourceClient.Update(u, metav1.UpdateOptions{})
+	return err
+}
+
+// refresh reads the group members from their leases, and rebuilds the ring when they changed.
+// The replica is a member as long as the lease it last renewed hasn't expired, whether it's read
+// or not.
+func (m *Membership) refresh() error {
+	list, err := m.client.Resource(leasesResource).Namespace(m.ns).List(metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{GroupLabel: m.group}).String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	var others []string
+	for i := range list.Items {
+		lease := &coordinationv1.Lease{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, lease); err != nil {
+			return err
+		}
+		if m.isLive(lease) && *lease.Spec.HolderIdentity != m.identity {
+			others = append(others, *lease.Spec.HolderIdentity)
+		}
+	}
+	m.update(others)
+	return nil
+}
+
+// otherMembers returns the identities of the group members but the replica.
+func (m *Membership) otherMembers() []string {
+	var others []string
+	for _, member := range m.Members() {
+		if member != m.identity {
+			others = append(others, member)
+		}
+	}
+	return others
+}
+
+// update rebuilds the ring from the given members, along with the replica while its lease hasn't
+// expired, and calls the registered functions when the members changed.
+func (m *Membership) update(others []string) {
+	m.mu.Lock()
+	members := others
+	if !m.renewed.IsZero() && m.now().Before(m.renewed.Add(m.leaseDuration)) {
+		members = append([]string{m.identity}, others...)
+	}
+	ring := NewRing(members)
+	changed := !reflect.DeepEqual(m.ring.Members(), ring.Members())
+	if changed {
+		m.ring = ring
+	}
+	onChange := append([]func(){}, m.onChange...)
+	m.mu.Unlock()
+
+	if changed {
+		shardingLog.Info("Shard members changed", "Members", ring.Members())
+		for _, f := range onChange {
+			f()
+		}
+	}
+}
+
+// isLive returns whether the given lease is held and not expired.
+func (m *Membership) isLive(lease *coordinationv1.Lease) bool {
+	spec := lease.Spec
+	if spec.HolderIdentity == nil || *spec.HolderIdentity == "" || spec.RenewTime == nil ||
+		spec.LeaseDurationSeconds == nil {
+		return false
+	}
+	expiry := spec.RenewTime.Add(time.Duration(*spec.LeaseDurationSeconds) * time.Second)
+	return m.now().Before(expiry)
+}
+
+// acquireTime returns the acquire time of the given lease.
+func acquireTime(u *unstructured.Unstructured) (*metav1.MicroTime, error) {
+	lease := &coordinationv1.Lease{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, lease); err != nil {
+		return nil, err
+	}
+	return lease.Spec.AcquireTime, nil
+}
+
+// toUnstructured converts the given lease to an unstructured object.
+func toUnstructured(lease *coordinationv1.Lease) (*unstructured.Unstructured, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(lease)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
@@ -0,0 +1,116 @@
+package sharding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// ownedBy returns the namespaces owned by the given membership.
+func ownedBy(m *Membership, names []string) map[string]bool {
+	owned := make(map[string]bool)
+	for _, ns := range names {
+		if m.Owns(ns) {
+			owned[ns] = true
+		}
+	}
+	return owned
+}
+
+func TestMembership(t *testing.T) {
+	client := fake.NewSimpleDynamicClient(runtime.NewScheme())
+	now := time.Now()
+	clock := func() time.Time { return now }
+	names := namespaces(200)
+
+	first := NewMembership(client, "operators", "sbo", "first", 30*time.Second)
+	first.now = clock
+	second := NewMembership(client, "operators", "sbo", "second", 30*time.Second)
+	second.now = clock
+
+	require.Empty(t, ownedBy(first, names), "namespaces are owned once joined")
+	require.NoError(t, first.Join())
+	require.Equal(t, []string{"first"}, first.Members())
+	require.Len(t, ownedBy(first, names), len(names))
+
+	changes := 0
+	first.OnChange(func() { changes++ })
+	require.NoError(t, second.Join())
+	require.NoError(t, first.refresh())
+	require.Equal(t, 1, changes)
+	require.Equal(t, []string{"first", "second"}, first.Members())
+	require.Equal(t, first.Members(), second.Members())
+
+	t.Run("namespaces shared between members", func(t *testing.T) {
+		firstOwned, secondOwned := ownedBy(first, names), ownedBy(second, names)
+		require.NotEmpty(t, firstOwned)
+		require.NotEmpty(t, secondOwned)
+		require.Len(t, firstOwned, len(names)-len(secondOwned))
+		for ns := range firstOwned {
+			require.False(t, secondOwned[ns], "namespace %s owned twice", ns)
+		}
+	})
+
+	t.Run("unchanged members", func(t *testing.T) {
+		require.NoError(t, first.renew())
+		require.NoError(t, first.refresh())
+		require.Equal(t, 1, changes)
+	})
+
+	t.Run("expired lease", func(t *testing.T) {
+		now = now.Add(20 * time.Second)
+		require.NoError(t, first.renew())
+		now = now.Add(20 * time.Second)
+		require.NoError(t, first.refresh())
+		require.Equal(t, 2, changes)
+		require.Equal(t, []string{"first"}, first.Members())
+		require.Len(t, ownedBy(first, names), len(names))
+	})
+
+	t.Run("lease not renewed", func(t *testing.T) {
+		reactions := client.ReactionChain
+		client.PrependReactor("*", "leases", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.NewServiceUnavailable("unreachable")
+		})
+		first.sync()
+		require.Equal(t, 2, changes, "the lease hasn't expired yet")
+		require.Len(t, ownedBy(first, names), len(names))
+
+		now = now.Add(20 * time.Second)
+		first.sync()
+		require.Equal(t, 3, changes)
+		require.Empty(t, first.Members())
+		require.Empty(t, ownedBy(first, names), "namespaces of an expired lease are owned by the other members")
+
+		client.ReactionChain = reactions
+		first.sync()
+		require.Equal(t, 4, changes)
+		require.Equal(t, []string{"first"}, first.Members())
+	})
+
+	t.Run("expired lease of the replica", func(t *testing.T) {
+		require.NoError(t, second.renew())
+		now = now.Add(40 * time.Second)
+		require.NoError(t, second.renew())
+		require.NoError(t, first.refresh())
+		require.Equal(t, []string{"second"}, first.Members(), "the replica lease is read but expired")
+		require.Empty(t, ownedBy(first, names))
+		require.NoError(t, first.renew())
+		require.NoError(t, first.refresh())
+		require.Equal(t, []string{"first", "second"}, first.Members())
+	})
+
+	t.Run("lease released on stop", func(t *testing.T) {
+		stop := make(chan struct{})
+		close(stop)
+		require.NoError(t, first.Start(stop))
+		_, err := client.Resource(leasesResource).Namespace("operators").Get(first.leaseName(), metav1.GetOptions{})
+		require.True(t, errors.IsNotFound(err))
+	})
+}
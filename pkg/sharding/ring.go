@@ -0,0 +1,76 @@
+// Package sharding spreads namespaces across the operator replicas, each replica owning the
+// namespaces a consistent hash ring of the live replicas assigns to it.
+package sharding
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+)
+
+// virtualNodes is the number of points each member takes on the ring, so namespaces are spread
+// evenly, and a member joining or leaving only moves its share of them.
+const virtualNodes = 128
+
+// Ring is a consistent hash ring assigning keys to members.
+type Ring struct {
+	members []string
+	// points are the sorted hashes of the members' virtual nodes.
+	points []uint32
+	owners map[uint32]string
+}
+
+// hash returns the position of the given key on the ring.
+func hash(key string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return h.Sum32()
+}
+
+// NewRing returns a ring of the given members; the same members give the same ring, whatever their
+// order.
+func NewRing(members []string) *Ring {
+	r := &Ring{owners: make(map[uint32]string)}
+	seen := make(map[string]bool)
+	for _, member := range members {
+		if seen[member] {
+			continue
+		}
+		seen[member] = true
+		r.members = append(r.members, member)
+	}
+	sort.Strings(r.members)
+
+	for _, member := range r.members {
+		for i := 0; i < virtualNodes; i++ {
+			point := hash(fmt.Sprintf("%s#%d", member, i))
+			// members are sorted, so colliding points go to the same member on every replica
+			if _, taken := r.owners[point]; taken {
+				continue
+			}
+			r.owners[point] = member
+			r.points = append(r.points, point)
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i] < r.points[j] })
+	return r
+}
+
+// Members returns the sorted members of the ring.
+func (r *Ring) Members() []string {
+	return append([]string{}, r.members...)
+}
+
+// Owner returns the member owning the given key: the member of the first point following the key
+// on the ring; empty when the ring has no members.
+func (r *Ring) Owner(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	h := hash(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.owners[r.points[i]]
+}
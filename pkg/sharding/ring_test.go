@@ -0,0 +1,57 @@
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// namespaces returns n namespace names.
+func namespaces(n int) []string {
+	names := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		names = append(names, fmt.Sprintf("tenant-%d", i))
+	}
+	return names
+}
+
+func TestRing(t *testing.T) {
+	t.Run("no members", func(t *testing.T) {
+		require.Equal(t, "", NewRing(nil).Owner("tenant"))
+	})
+
+	t.Run("deterministic owners", func(t *testing.T) {
+		ring := NewRing([]string{"a", "b", "c"})
+		other := NewRing([]string{"c", "a", "b", "a"})
+		require.Equal(t, []string{"a", "b", "c"}, other.Members())
+		for _, ns := range namespaces(100) {
+			require.Equal(t, ring.Owner(ns), other.Owner(ns))
+		}
+	})
+
+	t.Run("namespaces spread across members", func(t *testing.T) {
+		ring := NewRing([]string{"a", "b", "c"})
+		counts := make(map[string]int)
+		for _, ns := range namespaces(3000) {
+			counts[ring.Owner(ns)]++
+		}
+		require.Len(t, counts, 3)
+		for member, count := range counts {
+			require.True(t, count > 600, "member %s owns %d namespaces out of 3000", member, count)
+		}
+	})
+
+	t.Run("joining member only takes namespaces", func(t *testing.T) {
+		before := NewRing([]string{"a", "b"})
+		after := NewRing([]string{"a", "b", "c"})
+		moved := 0
+		for _, ns := range namespaces(1000) {
+			if owner := after.Owner(ns); owner != before.Owner(ns) {
+				require.Equal(t, "c", owner, "namespace %s moved between remaining members", ns)
+				moved++
+			}
+		}
+		require.True(t, moved > 0)
+	})
+}
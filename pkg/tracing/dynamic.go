@@ -0,0 +1,198 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// ResourceKey is the group, version and resource the API call is made against.
+	ResourceKey = attribute.Key("k8s.resource")
+	// NamespaceKey is the namespace the API call is made against.
+	NamespaceKey = attribute.Key("k8s.namespace")
+	// NameKey is the name of the object the API call is made against.
+	NameKey = attribute.Key("k8s.name")
+	// ResultKey is the outcome of the API call, either "success" or the failure reason.
+	ResultKey = attribute.Key("k8s.result")
+)
+
+// successResult is the ResultKey value of API calls which succeeded.
+const successResult = "success"
+
+// dynamicClient is a dynamic.Interface creating a span for each API call.
+type dynamicClient struct {
+	ctx    context.Context
+	client dynamic.Interface
+}
+
+var _ dynamic.Interface = (*dynamicClient)(nil)
+
+// NewDynamicClient returns a dynamic client which API calls are traced as children of the span
+// found in ctx.
+func NewDynamicClient(ctx context.Context, client dynamic.Interface) dynamic.Interface {
+	// avoid tracing calls twice when the client is already traced
+	if traced, ok := client.(*dynamicClient); ok {
+		client = traced.client
+	}
+	return &dynamicClient{ctx: ctx, client: client}
+}
+
+func (c *dynamicClient) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &resourceClient{
+		ctx:      c.ctx,
+		gvr:      gvr,
+		client:   c.client.Resource(gvr),
+		resource: c.client.Resource(gvr),
+	}
+}
+
+// resourceClient traces the API calls made against a single resource.
+type resourceClient struct {
+	ctx       context.Context
+	gvr       schema.GroupVersionResource
+	namespace string
+	// client is the namespaceable client the resource client was created from.
+	client dynamic.NamespaceableResourceInterface
+	// resource is the client API calls are delegated to, either client or one of its namespaces.
+	resource dynamic.ResourceInterface
+}
+
+var _ dynamic.NamespaceableResourceInterface = (*resourceClient)(nil)
+
+func (c *resourceClient) Namespace(ns string) dynamic.ResourceInterface {
+	return &resourceClient{
+		ctx:       c.ctx,
+		gvr:       c.gvr,
+		namespace: ns,
+		client:    c.client,
+		resource:  c.client.Namespace(ns),
+	}
+}
+
+// trace runs call within a span describing the API call.
+func (c *resourceClient) trace(verb string, name string, call func() error) {
+	attrs := []attribute.KeyValue{ResourceKey.String(c.gvr.String())}
+	if c.namespace != "" {
+		attrs = append(attrs, NamespaceKey.String(c.namespace))
+	}
+	if name != "" {
+		attrs = append(attrs, NameKey.String(name))
+	}
+	_, span := StartSpan(c.ctx, "dynamic."+verb, attrs...)
+
+	err := call()
+
+	result := successResult
+	if err != nil {
+		result = string(k8serrors.ReasonForError(err))
+		if result == "" {
+			result = "Error"
+		}
+	}
+	span.SetAttributes(ResultKey.String(result))
+	EndSpan(span, err)
+}
+
+func (c *resourceClient) Create(
+	obj *unstructured.Unstructured,
+	options metav1.CreateOptions,
+	subresources ...string,
+) (created *unstructured.Unstructured, err error) {
+	c.trace("Create", obj.GetName(), func() error {
+		created, err = c.resource.Create(obj, options, subresources...)
+		return err
+	})
+	return created, err
+}
+
+func (c *resourceClient) Update(
+	obj *unstructured.Unstructured,
+	options metav1.UpdateOptions,
+	subresources ...string,
+) (updated *unstructured.Unstructured, err error) {
+	c.trace("Update", obj.GetName(), func() error {
+		updated, err = c.resource.Update(obj, options, subresources...)
+		return err
+	})
+	return updated, err
+}
+
+func (c *resourceClient) UpdateStatus(
+	obj *unstructured.Unstructured,
+	options metav1.UpdateOptions,
+) (updated *unstructured.Unstructured, err error) {
+	c.trace("UpdateStatus", obj.GetName(), func() error {
+		updated, err = c.resource.UpdateStatus(obj, options)
+		return err
+	})
+	return updated, err
+}
+
+func (c *resourceClient) Delete(name string, options *metav1.DeleteOptions, subresources ...string) (err error) {
+	c.trace("Delete", name, func() error {
+		err = c.resource.Delete(name, options, subresources...)
+		return err
+	})
+	return err
+}
+
+func (c *resourceClient) DeleteCollection(
+	options *metav1.DeleteOptions,
+	listOptions metav1.ListOptions,
+) (err error) {
+	c.trace("DeleteCollection", "", func() error {
+		err = c.resource.DeleteCollection(options, listOptions)
+		return err
+	})
+	return err
+}
+
+func (c *resourceClient) Get(
+	name string,
+	options metav1.GetOptions,
+	subresources ...string,
+) (obj *unstructured.Unstructured, err error) {
+	c.trace("Get", name, func() error {
+		obj, err = c.resource.Get(name, options, subresources...)
+		return err
+	})
+	return obj, err
+}
+
+func (c *resourceClient) List(opts metav1.ListOptions) (list *unstructured.UnstructuredList, err error) {
+	c.trace("List", "", func() error {
+		list, err = c.resource.List(opts)
+		return err
+	})
+	return list, err
+}
+
+func (c *resourceClient) Watch(opts metav1.ListOptions) (w watch.Interface, err error) {
+	c.trace("Watch", "", func() error {
+		w, err = c.resource.Watch(opts)
+		return err
+	})
+	return w, err
+}
+
+func (c *resourceClient) Patch(
+	name string,
+	pt types.PatchType,
+	data []byte,
+	options metav1.PatchOptions,
+	subresources ...string,
+) (patched *unstructured.Unstructured, err error) {
+	c.trace("Patch", name, func() error {
+		patched, err = c.resource.Patch(name, pt, data, options, subresources...)
+		return err
+	})
+	return patched, err
+}
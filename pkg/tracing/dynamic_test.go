@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+)
+
+// recordSpans installs a tracer provider recording all ended spans.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func attributesOf(span sdktrace.ReadOnlySpan) map[attribute.Key]string {
+	attrs := make(map[attribute.Key]string)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value.Emit()
+	}
+	return attrs
+}
+
+func TestDynamicClient(t *testing.T) {
+	recorder := recordSpans(t)
+
+	secret := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "creds"},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	fake := fakedynamic.NewSimpleDynamicClient(scheme, secret)
+	secretsGVR := corev1.SchemeGroupVersion.WithResource("secrets")
+
+	ctx, parent := StartSpan(context.Background(), "parent")
+	client := NewDynamicClient(ctx, NewDynamicClient(context.Background(), fake))
+
+	_, err := client.Resource(secretsGVR).Namespace("ns").Get("creds", metav1.GetOptions{})
+	require.NoError(t, err)
+	_, err = client.Resource(secretsGVR).Namespace("ns").Get("missing", metav1.GetOptions{})
+	require.Error(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3, "calls should be traced once, even when the client is wrapped twice")
+
+	found, missing := spans[0], spans[1]
+	for _, span := range []sdktrace.ReadOnlySpan{found, missing} {
+		require.Equal(t, "dynamic.Get", span.Name())
+		require.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	}
+
+	require.Equal(t, map[attribute.Key]string{
+		ResourceKey:  "/v1, Resource=secrets",
+		NamespaceKey: "ns",
+		NameKey:      "creds",
+		ResultKey:    successResult,
+	}, attributesOf(found))
+	require.Equal(t, codes.Unset, found.Status().Code)
+
+	require.Equal(t, "NotFound", attributesOf(missing)[ResultKey])
+	require.Equal(t, codes.Error, missing.Status().Code)
+}
@@ -0,0 +1,125 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationName identifies the spans created by the operator.
+	instrumentationName = "github.com/redhat-developer/service-binding-operator"
+
+	// ExporterEnvVar selects the exporter used to send spans.
+	ExporterEnvVar = "SERVICE_BINDING_OPERATOR_TRACING_EXPORTER"
+	// FileEnvVar is the path of the file spans are written to by the "file" exporter.
+	FileEnvVar = "SERVICE_BINDING_OPERATOR_TRACING_FILE"
+
+	// NoneExporter disables tracing; it is the default.
+	NoneExporter = "none"
+	// OTLPExporter sends spans using OTLP over gRPC, configured by the standard OTEL_EXPORTER_OTLP_*
+	// environment variables.
+	OTLPExporter = "otlp"
+	// StdoutExporter writes spans to the standard output.
+	StdoutExporter = "stdout"
+	// FileExporter writes spans to the file informed in FileEnvVar.
+	FileExporter = "file"
+)
+
+// ShutdownFunc flushes pending spans and releases the resources used by the exporter.
+type ShutdownFunc func(ctx context.Context) error
+
+// noopShutdown is returned when tracing is disabled.
+func noopShutdown(context.Context) error { return nil }
+
+// Setup configures the global tracer provider according to ExporterEnvVar. Spans are discarded
+// when no exporter is selected.
+func Setup(ctx context.Context, serviceName string) (ShutdownFunc, error) {
+	exporterName := os.Getenv(ExporterEnvVar)
+
+	var exporter sdktrace.SpanExporter
+	var closer io.Closer
+	switch exporterName {
+	case "", NoneExporter:
+		return noopShutdown, nil
+	case OTLPExporter:
+		otlpExporter, err := otlptrace.New(ctx, otlptracegrpc.NewClient())
+		if err != nil {
+			return nil, err
+		}
+		exporter = otlpExporter
+	case StdoutExporter:
+		stdoutExporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, err
+		}
+		exporter = stdoutExporter
+	case FileExporter:
+		path := os.Getenv(FileEnvVar)
+		if path == "" {
+			return nil, fmt.Errorf("%s is required by the %q exporter", FileEnvVar, FileExporter)
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, err
+		}
+		fileExporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		exporter = fileExporter
+		closer = f
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", exporterName)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(serviceName),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			if closeErr := closer.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
+
+// StartSpan starts a span named after name as a child of the span found in ctx, if any.
+func StartSpan(
+	ctx context.Context,
+	name string,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends span, recording err and flagging the span as failed when informed.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -8,6 +8,7 @@ import (
 	olmv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	apiextensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextensionv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -185,6 +186,15 @@ func (f *Fake) AddMockedUnstructuredDatabaseCRD() *unstructured.Unstructured {
 	return c
 }
 
+func (f *Fake) AddMockedUnstructuredDatabaseCRDV1() *unstructured.Unstructured {
+	require.NoError(f.t, apiextensionv1.AddToScheme(f.S))
+	c, err := UnstructuredDatabaseCRDV1Mock(f.ns)
+	require.NoError(f.t, err)
+	f.S.AddKnownTypes(apiextensionv1.SchemeGroupVersion, &apiextensionv1.CustomResourceDefinition{})
+	f.objs = append(f.objs, c)
+	return c
+}
+
 func (f *Fake) AddMockedUnstructuredPostgresDatabaseCR(ref string) *unstructured.Unstructured {
 	d, err := UnstructuredPostgresDatabaseCRMock(f.ns, ref)
 	require.NoError(f.t, err)
@@ -10,6 +10,7 @@ import (
 	olmv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextensionv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -126,6 +127,50 @@ func DatabaseCRDMock(ns string) apiextensionv1beta1.CustomResourceDefinition {
 	return crd
 }
 
+// DatabaseCRDV1Mock returns an apiextensions.k8s.io/v1 CRD for the database service, declaring its
+// binding information through descriptor and version specific annotations.
+func DatabaseCRDV1Mock(ns string) apiextensionv1.CustomResourceDefinition {
+	CRDPlural := "databases"
+	FullCRDName := CRDPlural + "." + CRDName
+	annotations := map[string]string{
+		"x-descriptors.service.binding/status.dbCredentials": `
+- urn:alm:descriptor:io.kubernetes:Secret
+- service.binding
+`,
+		CRDVersion + ".service.binding/dbName": "path={.spec.dbName}",
+		"v1.service.binding/dbName":            "path={.spec.name}",
+	}
+
+	return apiextensionv1.CustomResourceDefinition{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "CustomResourceDefinition",
+			APIVersion: "apiextensions.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   ns,
+			Name:        FullCRDName,
+			Annotations: annotations,
+		},
+		Spec: apiextensionv1.CustomResourceDefinitionSpec{
+			Group: CRDName,
+			Versions: []apiextensionv1.CustomResourceDefinitionVersion{
+				{Name: CRDVersion, Served: true, Storage: true},
+				{Name: "v1", Served: false},
+			},
+			Scope: apiextensionv1.NamespaceScoped,
+			Names: apiextensionv1.CustomResourceDefinitionNames{
+				Plural: CRDPlural,
+				Kind:   CRDKind,
+			},
+		},
+	}
+}
+
+func UnstructuredDatabaseCRDV1Mock(ns string) (*unstructured.Unstructured, error) {
+	crd := DatabaseCRDV1Mock(ns)
+	return converter.ToUnstructured(&crd)
+}
+
 func UnstructuredDatabaseCRDMock(ns string) (*unstructured.Unstructured, error) {
 	crd := DatabaseCRDMock(ns)
 	return converter.ToUnstructured(&crd)